@@ -0,0 +1,16 @@
+provider "mondoo" {}
+
+data "mondoo_provider_identity" "current" {}
+
+output "service_account_mrn" {
+  description = "MRN of the service account the provider is authenticated as"
+  value       = data.mondoo_provider_identity.current.mrn
+}
+
+# Fail early if the pipeline runs with the wrong service account
+check "production_service_account" {
+  assert {
+    condition     = data.mondoo_provider_identity.current.scope_mrn == "//captain.api.mondoo.app/organizations/lunalectric"
+    error_message = "The provider must be authenticated with a service account of the lunalectric organization."
+  }
+}
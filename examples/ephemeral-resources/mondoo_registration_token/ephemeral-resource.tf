@@ -0,0 +1,17 @@
+provider "mondoo" {
+  space = "hungry-poet-123456"
+}
+
+# Generate a short-lived registration token that is never stored in the Terraform state
+ephemeral "mondoo_registration_token" "token" {
+  description = "Register EC2 instances"
+  expires_in  = "1h"
+}
+
+# Share the token with the instances through a write-only attribute
+resource "aws_ssm_parameter" "registration_token" {
+  name             = "/mondoo/registration-token"
+  type             = "SecureString"
+  value_wo         = ephemeral.mondoo_registration_token.token.result
+  value_wo_version = 1
+}
@@ -0,0 +1,29 @@
+variable "mondoo_space" {
+  description = "Mondoo Space"
+  type        = string
+}
+
+# Create a short-lived service account for the duration of the Terraform run, its credential is
+# never stored in the Terraform state and the service account is deleted once Terraform is done
+ephemeral "mondoo_service_account_credential" "editor" {
+  space_id = var.mondoo_space
+  name     = "Terraform run"
+  roles = [
+    "//iam.api.mondoo.app/roles/editor",
+  ]
+}
+
+# Manage the space with the least privileged credential
+provider "mondoo" {
+  alias       = "space_editor"
+  credentials = base64decode(ephemeral.mondoo_service_account_credential.editor.credential)
+  space       = var.mondoo_space
+}
+
+resource "mondoo_policy_assignment" "space" {
+  provider = mondoo.space_editor
+
+  policies = [
+    "//policy.api.mondoo.app/policies/mondoo-aws-security",
+  ]
+}
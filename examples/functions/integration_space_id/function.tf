@@ -0,0 +1,14 @@
+provider "mondoo" {
+  space = "hungry-poet-123456"
+}
+
+resource "mondoo_integration_domain" "domain_integration" {
+  host  = "mondoo.com"
+  https = true
+  http  = false
+}
+
+output "integration_space_id" {
+  description = "The ID of the space of the integration"
+  value       = provider::mondoo::integration_space_id(mondoo_integration_domain.domain_integration.mrn)
+}
@@ -0,0 +1,4 @@
+output "org_mrn" {
+  description = "The MRN of the organization"
+  value       = provider::mondoo::org_mrn("lunalectric")
+}
@@ -0,0 +1,13 @@
+locals {
+  integration = provider::mondoo::parse_mrn("//captain.api.mondoo.app/spaces/hungry-poet-123456/integrations/2Abd3VXhNqnQk7Uw2sq0z7BfDwh")
+}
+
+output "integration_id" {
+  description = "The ID of the integration"
+  value       = local.integration.ids["integrations"]
+}
+
+output "space_id" {
+  description = "The ID of the space of the integration"
+  value       = local.integration.ids["spaces"]
+}
@@ -0,0 +1,4 @@
+output "space_id" {
+  description = "The ID of the space"
+  value       = provider::mondoo::space_id("//captain.api.mondoo.app/spaces/hungry-poet-123456")
+}
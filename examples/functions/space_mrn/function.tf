@@ -0,0 +1,4 @@
+output "space_mrn" {
+  description = "The MRN of the space"
+  value       = provider::mondoo::space_mrn("hungry-poet-123456")
+}
@@ -0,0 +1,6 @@
+# Import an exception by the ID of its exception group
+terraform import mondoo_exception.exception "//captain.api.mondoo.app/spaces/hungry-poet-123456,2gtxPMEQjCwrzFUBESADbSNKu5V"
+
+# Import an exception by its targets, exceptions holding several kinds of targets are imported
+# by the targets of one kind at a time
+terraform import mondoo_exception.exception "//captain.api.mondoo.app/spaces/hungry-poet-123456,//policy.api.mondoo.app/queries/mondoo-tls-security-mitigate-beast"
@@ -25,3 +25,17 @@ resource "mondoo_exception" "exception" {
   action        = "SNOOZE"
   check_mrns    = ["//policy.api.mondoo.app/queries/mondoo-tls-security-mitigate-beast"]
 }
+
+resource "mondoo_exception" "controls" {
+  justification = "handled by the network team"
+  action        = "OUT_OF_SCOPE"
+  control_mrns  = ["//policy.api.mondoo.app/frameworks/cis-controls-8/controls/cis-controls-8.1.1"]
+}
+
+resource "mondoo_exception" "cves" {
+  valid_until        = "2024-12-11"
+  justification      = "patch scheduled for the next maintenance window"
+  action             = "SNOOZE"
+  vulnerability_mrns = ["//vadvisor.api.mondoo.app/advisories/DSA-5724-1"]
+  apply_to_cves      = true
+}
@@ -0,0 +1,3 @@
+# Import all the policy assignments of a space by its ID, policies in different states
+# are imported into `assignments`
+terraform import mondoo_policy_assignment.space hungry-poet-123456
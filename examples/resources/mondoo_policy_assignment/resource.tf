@@ -7,3 +7,11 @@ resource "mondoo_policy_assignment" "space" {
     "//policy.api.mondoo.app/policies/mondoo-aws-security",
   ]
 }
+
+# mix policy states in a single resource
+resource "mondoo_policy_assignment" "mixed" {
+  assignments = {
+    "//policy.api.mondoo.app/policies/mondoo-linux-security"      = "enabled"
+    "//policy.api.mondoo.app/policies/mondoo-kubernetes-security" = "preview"
+  }
+}
@@ -0,0 +1,2 @@
+# Import all the query pack assignments of a space by its ID
+terraform import mondoo_querypack_assignment.space hungry-poet-123456
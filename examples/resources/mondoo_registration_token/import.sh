@@ -0,0 +1,2 @@
+# Import a registration token by its MRN, the token itself cannot be recovered
+terraform import 'mondoo_registration_token.token[0]' "//agents.api.mondoo.app/spaces/hungry-poet-123456/registration_tokens/2gtxPMEQjCwrzFUBESADbSNKu5V"
@@ -31,6 +31,8 @@ resource "mondoo_registration_token" "token" {
   no_expiration = true
   # define optional expiration
   # expires_in = "1h"
+  # replace the token a week before it expires
+  # rotate_before = "168h"
   depends_on = [
     mondoo_space.my_space
   ]
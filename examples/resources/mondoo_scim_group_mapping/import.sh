@@ -0,0 +1,2 @@
+# Import the mapping of a SCIM 2.0 group by the organization ID and the group display name
+terraform import mondoo_scim_group_mapping.mondoo_admin "reverent-ride-275852/MondooAdmin"
@@ -0,0 +1,2 @@
+# Import a service account by its MRN, the credential of imported service accounts is empty
+terraform import mondoo_service_account.service_account "//agents.api.mondoo.app/spaces/hungry-poet-123456/serviceaccounts/2gtxPMEQjCwrzFUBESADbSNKu5V"
@@ -6,10 +6,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -48,9 +54,25 @@ type scoreModel struct {
 }
 
 type spaceAssetDataSourceModel struct {
-	SpaceID  types.String            `tfsdk:"space_id"`
-	SpaceMrn types.String            `tfsdk:"space_mrn"`
-	Assets   []assetsDataSourceModel `tfsdk:"assets"`
+	SpaceID      types.String            `tfsdk:"space_id"`
+	SpaceMrn     types.String            `tfsdk:"space_mrn"`
+	PageSize     types.Int64             `tfsdk:"page_size"`
+	MaxResults   types.Int64             `tfsdk:"max_results"`
+	Filter       *assetsFilterModel      `tfsdk:"filter"`
+	TotalCount   types.Int64             `tfsdk:"total_count"`
+	MatchedCount types.Int64             `tfsdk:"matched_count"`
+	Assets       []assetsDataSourceModel `tfsdk:"assets"`
+}
+
+type assetsFilterModel struct {
+	AssetTypes    []types.String          `tfsdk:"asset_types"`
+	States        []types.String          `tfsdk:"states"`
+	NameRegex     types.String            `tfsdk:"name_regex"`
+	MinScoreGrade types.String            `tfsdk:"min_score_grade"`
+	MaxScoreGrade types.String            `tfsdk:"max_score_grade"`
+	Annotations   map[string]types.String `tfsdk:"annotations"`
+	UpdatedAfter  types.String            `tfsdk:"updated_after"`
+	UpdatedBefore types.String            `tfsdk:"updated_before"`
 }
 
 func (d *assetsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -83,6 +105,75 @@ func (d *assetsDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 					}...),
 				},
 			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The number of assets fetched per request, defaults to `%d`.", defaultAssetsPageSize),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000),
+				},
+			},
+			"max_results": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of assets to return, if not set, all assets in the space are returned. With a filter, all the assets are still fetched to count the matching ones.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"filter": schema.SingleNestedAttribute{
+				MarkdownDescription: "Only fetch the assets matching all the configured filters.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"asset_types": schema.ListAttribute{
+						MarkdownDescription: "Only include assets of one of these types, e.g. `aws-ec2-instance`.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"states": schema.ListAttribute{
+						MarkdownDescription: "Only include assets in one of these states, e.g. `ONLINE`.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"name_regex": schema.StringAttribute{
+						MarkdownDescription: "Only include assets whose name matches this regular expression.",
+						Optional:            true,
+					},
+					"min_score_grade": schema.StringAttribute{
+						MarkdownDescription: "Only include assets with this score grade or a better one, e.g. `C` includes `A`, `B` and `C`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(assetScoreGrades...),
+						},
+					},
+					"max_score_grade": schema.StringAttribute{
+						MarkdownDescription: "Only include assets with this score grade or a worse one, e.g. `D` includes `D` and `F`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(assetScoreGrades...),
+						},
+					},
+					"annotations": schema.MapAttribute{
+						MarkdownDescription: "Only include assets having all these annotations.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"updated_after": schema.StringAttribute{
+						MarkdownDescription: "Only include assets updated after this time, in RFC3339 format.",
+						Optional:            true,
+					},
+					"updated_before": schema.StringAttribute{
+						MarkdownDescription: "Only include assets updated before this time, in RFC3339 format.",
+						Optional:            true,
+					},
+				},
+			},
+			"total_count": schema.Int64Attribute{
+				MarkdownDescription: "The total number of assets in the space, filters are not taken into account.",
+				Computed:            true,
+			},
+			"matched_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of assets in the space matching the filter, if it is greater than the number of `assets`, the list was limited by `max_results`. Without filter, this is the same as `total_count`.",
+				Computed:            true,
+			},
 			"assets": schema.ListNestedAttribute{
 				MarkdownDescription: "The list of assets in the space.",
 				Computed:            true,
@@ -196,12 +287,37 @@ func (d *assetsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	maxResults := int(data.MaxResults.ValueInt64())
+	var filter *assetsFilter
+	if data.Filter != nil {
+		var diags diag.Diagnostics
+		filter, diags = newAssetsFilter(data.Filter)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// the filters are applied on our side, all the assets are needed to count the matches
+		maxResults = 0
+	}
+
 	// Read API call logic
-	assets, err := d.client.GetAssets(ctx, spaceMrn)
+	assets, err := d.client.GetAssets(ctx, spaceMrn,
+		int(data.PageSize.ValueInt64()),
+		maxResults,
+	)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to fetch assets", err.Error())
 		return
 	}
+	data.TotalCount = types.Int64Value(int64(assets.TotalCount))
+	data.MatchedCount = data.TotalCount
+	if filter != nil {
+		assets.Edges = slices.DeleteFunc(assets.Edges, func(edge AssetEdge) bool { return !filter.matches(edge.Node) })
+		data.MatchedCount = types.Int64Value(int64(len(assets.Edges)))
+		if limit := int(data.MaxResults.ValueInt64()); limit > 0 && len(assets.Edges) > limit {
+			assets.Edges = assets.Edges[:limit]
+		}
+	}
 
 	// Map API response to the model
 	data.Assets = make([]assetsDataSourceModel, len(assets.Edges))
@@ -243,3 +359,105 @@ func convertToAnnotationsModel(kv KeyValue) *annotationsModel {
 		Value: kv.Value,
 	}
 }
+
+// assetScoreGrades are the score grades of an asset from best to worst.
+var assetScoreGrades = []string{"A", "B", "C", "D", "F"}
+
+// scoreGradeRank returns the position of the grade in assetScoreGrades, or -1 for assets
+// without a grade.
+func scoreGradeRank(grade string) int {
+	return slices.Index(assetScoreGrades, strings.ToUpper(grade))
+}
+
+// assetsFilter matches the assets against the filters configured on the data source.
+type assetsFilter struct {
+	assetTypes    []string
+	states        []string
+	name          *regexp.Regexp
+	minGrade      string
+	maxGrade      string
+	annotations   map[string]string
+	updatedAfter  time.Time
+	updatedBefore time.Time
+}
+
+func newAssetsFilter(model *assetsFilterModel) (*assetsFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	filter := &assetsFilter{
+		minGrade:    model.MinScoreGrade.ValueString(),
+		maxGrade:    model.MaxScoreGrade.ValueString(),
+		annotations: map[string]string{},
+	}
+	for _, assetType := range model.AssetTypes {
+		filter.assetTypes = append(filter.assetTypes, assetType.ValueString())
+	}
+	for _, state := range model.States {
+		filter.states = append(filter.states, state.ValueString())
+	}
+	for key, value := range model.Annotations {
+		filter.annotations[key] = value.ValueString()
+	}
+
+	if model.NameRegex.ValueString() != "" {
+		name, err := regexp.Compile(model.NameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("filter").AtName("name_regex"), "Invalid name_regex", err.Error())
+		}
+		filter.name = name
+	}
+	if model.UpdatedAfter.ValueString() != "" {
+		updatedAfter, err := time.Parse(time.RFC3339, model.UpdatedAfter.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("filter").AtName("updated_after"), "Invalid updated_after", err.Error())
+		}
+		filter.updatedAfter = updatedAfter
+	}
+	if model.UpdatedBefore.ValueString() != "" {
+		updatedBefore, err := time.Parse(time.RFC3339, model.UpdatedBefore.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("filter").AtName("updated_before"), "Invalid updated_before", err.Error())
+		}
+		filter.updatedBefore = updatedBefore
+	}
+	return filter, diags
+}
+
+func (f *assetsFilter) matches(asset AssetNode) bool {
+	if len(f.assetTypes) > 0 && !slices.ContainsFunc(f.assetTypes, func(t string) bool { return strings.EqualFold(t, asset.Asset_type) }) {
+		return false
+	}
+	if len(f.states) > 0 && !slices.ContainsFunc(f.states, func(s string) bool { return strings.EqualFold(s, asset.State) }) {
+		return false
+	}
+	if f.name != nil && !f.name.MatchString(asset.Name) {
+		return false
+	}
+
+	grade := scoreGradeRank(asset.Score.Grade)
+	if f.minGrade != "" && (grade < 0 || grade > scoreGradeRank(f.minGrade)) {
+		return false
+	}
+	if f.maxGrade != "" && (grade < 0 || grade < scoreGradeRank(f.maxGrade)) {
+		return false
+	}
+
+	for key, value := range f.annotations {
+		if !slices.Contains(asset.Annotations, KeyValue{Key: key, Value: value}) {
+			return false
+		}
+	}
+
+	if !f.updatedAfter.IsZero() || !f.updatedBefore.IsZero() {
+		updatedAt, err := time.Parse(time.RFC3339, asset.UpdatedAt)
+		if err != nil {
+			return false
+		}
+		if !f.updatedAfter.IsZero() && !updatedAt.After(f.updatedAfter) {
+			return false
+		}
+		if !f.updatedBefore.IsZero() && !updatedAt.Before(f.updatedBefore) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,218 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+var seedAssetsOnce sync.Once
+
+// testAccPreCheckAssets seeds the assets of the acceptance test space, assets can't be created
+// through the API, therefore these tests only run against the fake Mondoo API.
+func testAccPreCheckAssets(t *testing.T) {
+	if fakeAPI == nil {
+		t.Skip("assets can only be seeded in the fake Mondoo API")
+	}
+	seedAssetsOnce.Do(func() {
+		fakeAPI.addAssets(accSpace.MRN(),
+			fakeObject{"name": "web-1", "asset_type": "linux", "state": "ONLINE", "updatedAt": "2024-10-01T10:00:00Z", "score": fakeObject{"grade": "A", "value": 95}},
+			fakeObject{"name": "web-2", "asset_type": "linux", "state": "ONLINE", "updatedAt": "2024-10-02T10:00:00Z", "score": fakeObject{"grade": "B", "value": 80}},
+			fakeObject{"name": "db-1", "asset_type": "linux", "state": "OFFLINE", "updatedAt": "2024-09-01T10:00:00Z", "score": fakeObject{"grade": "D", "value": 40}},
+			fakeObject{"name": "aws-account", "asset_type": "aws", "state": "ONLINE", "updatedAt": "2024-10-03T10:00:00Z", "score": fakeObject{"grade": "C", "value": 65},
+				"annotations": []interface{}{fakeObject{"key": "env", "value": "production"}}},
+			fakeObject{"name": "k8s-cluster", "asset_type": "k8s-cluster", "state": "ONLINE", "updatedAt": "2024-08-15T10:00:00Z", "score": fakeObject{"grade": "F", "value": 10},
+				"annotations": []interface{}{fakeObject{"key": "env", "value": "staging"}}},
+		)
+	})
+}
+
+func TestAccAssetsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckAssets(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read all assets, one page at a time
+			{
+				Config: testAccAssetsDataSourceConfig(accSpace.ID(), `page_size = 2`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "total_count", "5"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "matched_count", "5"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "assets.#", "5"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "assets.0.name", "web-1"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "assets.4.name", "k8s-cluster"),
+				),
+			},
+			// Limit the number of assets
+			{
+				Config: testAccAssetsDataSourceConfig(accSpace.ID(), `page_size = 2
+  max_results = 3`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "total_count", "5"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "assets.#", "3"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "assets.2.name", "db-1"),
+				),
+			},
+			// Filter the assets, the limit applies to the matching assets
+			{
+				Config: testAccAssetsDataSourceConfig(accSpace.ID(), `page_size = 2
+  max_results = 2
+  filter = {
+    states          = ["online"]
+    max_score_grade = "C"
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "total_count", "5"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "matched_count", "2"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "assets.#", "2"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "assets.0.name", "aws-account"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "assets.1.name", "k8s-cluster"),
+				),
+			},
+			// All the matching assets are counted, even when the limit is reached
+			{
+				Config: testAccAssetsDataSourceConfig(accSpace.ID(), `max_results = 1
+  filter = {
+    states = ["online"]
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "total_count", "5"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "matched_count", "4"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "assets.#", "1"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "assets.0.name", "web-1"),
+				),
+			},
+			{
+				Config: testAccAssetsDataSourceConfig(accSpace.ID(), `filter = {
+    name_regex    = "^web-"
+    annotations   = {}
+    updated_after = "2024-10-01T12:00:00Z"
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "matched_count", "1"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "assets.#", "1"),
+					resource.TestCheckResourceAttr("data.mondoo_assets.test", "assets.0.name", "web-2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAssetsDataSourceConfig(spaceID, options string) string {
+	return fmt.Sprintf(`
+data "mondoo_assets" "test" {
+  space_id = %[1]q
+  %[2]s
+}
+`, spaceID, options)
+}
+
+func TestAssetsFilterMatches(t *testing.T) {
+	asset := AssetNode{
+		Name:        "i-0123456789",
+		State:       "ONLINE",
+		Asset_type:  "aws-ec2-instance",
+		UpdatedAt:   "2024-10-01T10:00:00Z",
+		Score:       AssetScore{Grade: "D", Value: 40},
+		Annotations: []KeyValue{{Key: "env", Value: "production"}},
+	}
+
+	tests := []struct {
+		name     string
+		filter   assetsFilterModel
+		expected bool
+	}{
+		{
+			name:     "No filters",
+			filter:   assetsFilterModel{},
+			expected: true,
+		},
+		{
+			name:     "Matching asset type",
+			filter:   assetsFilterModel{AssetTypes: []types.String{types.StringValue("aws-s3-bucket"), types.StringValue("aws-ec2-instance")}},
+			expected: true,
+		},
+		{
+			name:     "Other asset type",
+			filter:   assetsFilterModel{AssetTypes: []types.String{types.StringValue("aws-s3-bucket")}},
+			expected: false,
+		},
+		{
+			name:     "State is case-insensitive",
+			filter:   assetsFilterModel{States: []types.String{types.StringValue("online")}},
+			expected: true,
+		},
+		{
+			name:     "Name regex",
+			filter:   assetsFilterModel{NameRegex: types.StringValue("^i-[0-9]+$")},
+			expected: true,
+		},
+		{
+			name:     "Name regex not matching",
+			filter:   assetsFilterModel{NameRegex: types.StringValue("^web")},
+			expected: false,
+		},
+		{
+			name:     "Grade worse than the minimum",
+			filter:   assetsFilterModel{MinScoreGrade: types.StringValue("C")},
+			expected: false,
+		},
+		{
+			name:     "Grade within the thresholds",
+			filter:   assetsFilterModel{MinScoreGrade: types.StringValue("F"), MaxScoreGrade: types.StringValue("D")},
+			expected: true,
+		},
+		{
+			name:     "Grade better than the maximum",
+			filter:   assetsFilterModel{MaxScoreGrade: types.StringValue("F")},
+			expected: false,
+		},
+		{
+			name:     "Matching annotation",
+			filter:   assetsFilterModel{Annotations: map[string]types.String{"env": types.StringValue("production")}},
+			expected: true,
+		},
+		{
+			name:     "Annotation with another value",
+			filter:   assetsFilterModel{Annotations: map[string]types.String{"env": types.StringValue("staging")}},
+			expected: false,
+		},
+		{
+			name: "Updated within the window",
+			filter: assetsFilterModel{
+				UpdatedAfter:  types.StringValue("2024-09-30T00:00:00Z"),
+				UpdatedBefore: types.StringValue("2024-10-02T00:00:00Z"),
+			},
+			expected: true,
+		},
+		{
+			name:     "Updated before the window",
+			filter:   assetsFilterModel{UpdatedAfter: types.StringValue("2024-10-01T10:00:00Z")},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, diags := newAssetsFilter(&tt.filter)
+			assert.False(t, diags.HasError())
+			assert.Equal(t, tt.expected, filter.matches(asset))
+		})
+	}
+}
+
+func TestNewAssetsFilterInvalid(t *testing.T) {
+	_, diags := newAssetsFilter(&assetsFilterModel{
+		NameRegex:    types.StringValue("(unclosed"),
+		UpdatedAfter: types.StringValue(time.Now().Format(time.Kitchen)),
+	})
+	assert.Equal(t, 2, diags.ErrorsCount())
+}
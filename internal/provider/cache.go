@@ -0,0 +1,130 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// The kinds of cached queries, cache keys are prefixed with them.
+const (
+	cacheKindSpace        = "space"
+	cacheKindOrganization = "organization"
+	cacheKindPolicies     = "policies"
+	cacheKindFrameworks   = "frameworks"
+)
+
+// cacheInvalidations lists the kinds of cached queries affected by each mutation.
+var cacheInvalidations = map[string][]string{
+	"createSpace":            {cacheKindSpace},
+	"updateSpace":            {cacheKindSpace},
+	"deleteSpace":            {cacheKindSpace, cacheKindPolicies, cacheKindFrameworks},
+	"setCustomPolicy":        {cacheKindPolicies},
+	"setCustomQueryPack":     {cacheKindPolicies},
+	"deleteCustomPolicy":     {cacheKindPolicies},
+	"assignPolicy":           {cacheKindPolicies},
+	"unassignPolicy":         {cacheKindPolicies},
+	"uploadFramework":        {cacheKindFrameworks},
+	"applyFrameworkMutation": {cacheKindFrameworks},
+	"deleteFramework":        {cacheKindFrameworks},
+}
+
+// queryCache memoizes the results of read-only queries for the lifetime of the provider process,
+// a plan with many resources and data sources would otherwise send the same queries over and
+// over again. Concurrent identical queries are deduplicated, only one of them reaches the API.
+type queryCache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	// done is closed once the query finished
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+func newQueryCache() *queryCache {
+	return &queryCache{entries: map[string]*cacheEntry{}}
+}
+
+// cacheKey builds the key of a query from its kind and arguments.
+func cacheKey(kind string, args ...string) string {
+	return kind + "|" + strings.Join(args, "|")
+}
+
+// do returns the memoized result of the query identified by key, fetching it if needed. Errors
+// and empty results are shared with the concurrent callers but never memoized, the next call
+// tries again: what is missing now might be created by a resource later in the same run.
+func (c *queryCache) do(ctx context.Context, key string, fetch func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-entry.done:
+			return entry.value, entry.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	entry := &cacheEntry{done: make(chan struct{})}
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	entry.value, entry.err = fetch()
+	close(entry.done)
+
+	if entry.err != nil || emptyResult(entry.value) {
+		c.mu.Lock()
+		if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+	return entry.value, entry.err
+}
+
+// emptyResult checks if a query returned nothing, e.g. an object that does not exist (the API
+// answers with null) or an empty list.
+func emptyResult(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// invalidate forgets the memoized results of the provided kinds of queries.
+func (c *queryCache) invalidate(kinds ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		for _, kind := range kinds {
+			if strings.HasPrefix(key, kind+"|") {
+				delete(c.entries, key)
+			}
+		}
+	}
+}
+
+// cachedQuery runs fetch through the query cache of the client, if the client has one.
+func cachedQuery[T any](ctx context.Context, c *ExtendedGqlClient, key string, fetch func() (T, error)) (T, error) {
+	if c.cache == nil {
+		return fetch()
+	}
+	value, err := c.cache.do(ctx, key, func() (interface{}, error) {
+		return fetch()
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value.(T), nil
+}
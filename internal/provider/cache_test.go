@@ -0,0 +1,183 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Memoizes results", func(t *testing.T) {
+		c := newQueryCache()
+		fetches := 0
+		fetch := func() (interface{}, error) {
+			fetches++
+			return "hungry-poet", nil
+		}
+
+		for i := 0; i < 3; i++ {
+			value, err := c.do(ctx, cacheKey(cacheKindSpace, "//captain.api.mondoo.app/spaces/hungry-poet"), fetch)
+			require.NoError(t, err)
+			assert.Equal(t, "hungry-poet", value)
+		}
+		assert.Equal(t, 1, fetches)
+
+		// other arguments are another query
+		_, err := c.do(ctx, cacheKey(cacheKindSpace, "//captain.api.mondoo.app/spaces/other"), fetch)
+		require.NoError(t, err)
+		assert.Equal(t, 2, fetches)
+	})
+
+	t.Run("Deduplicates concurrent queries", func(t *testing.T) {
+		c := newQueryCache()
+		var fetches atomic.Int32
+		release := make(chan struct{})
+		fetch := func() (interface{}, error) {
+			fetches.Add(1)
+			<-release
+			return "hungry-poet", nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				value, err := c.do(ctx, cacheKey(cacheKindSpace, "hungry-poet"), fetch)
+				assert.NoError(t, err)
+				assert.Equal(t, "hungry-poet", value)
+			}()
+		}
+		close(release)
+		wg.Wait()
+		assert.Equal(t, int32(1), fetches.Load())
+	})
+
+	t.Run("Does not memoize errors", func(t *testing.T) {
+		c := newQueryCache()
+		fetches := 0
+		fetch := func() (interface{}, error) {
+			fetches++
+			if fetches == 1 {
+				return nil, errors.New("service unavailable")
+			}
+			return "hungry-poet", nil
+		}
+
+		_, err := c.do(ctx, cacheKey(cacheKindSpace, "hungry-poet"), fetch)
+		assert.Error(t, err)
+		value, err := c.do(ctx, cacheKey(cacheKindSpace, "hungry-poet"), fetch)
+		require.NoError(t, err)
+		assert.Equal(t, "hungry-poet", value)
+		assert.Equal(t, 2, fetches)
+	})
+
+	t.Run("Does not memoize empty results", func(t *testing.T) {
+		for _, empty := range []interface{}{nil, spacePayload{}, []Policy{}, []ComplianceFrameworksPayload(nil)} {
+			c := newQueryCache()
+			fetches := 0
+			fetch := func() (interface{}, error) {
+				fetches++
+				return empty, nil
+			}
+
+			for i := 0; i < 2; i++ {
+				_, err := c.do(ctx, cacheKey(cacheKindSpace, "hungry-poet"), fetch)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, 2, fetches, "%#v", empty)
+		}
+	})
+
+	t.Run("Invalidates by kind", func(t *testing.T) {
+		c := newQueryCache()
+		fetches := 0
+		fetch := func() (interface{}, error) {
+			fetches++
+			return fetches, nil
+		}
+
+		_, err := c.do(ctx, cacheKey(cacheKindPolicies, "hungry-poet", "POLICY", "true"), fetch)
+		require.NoError(t, err)
+		_, err = c.do(ctx, cacheKey(cacheKindFrameworks, "hungry-poet"), fetch)
+		require.NoError(t, err)
+
+		c.invalidate(cacheInvalidations["assignPolicy"]...)
+
+		value, err := c.do(ctx, cacheKey(cacheKindPolicies, "hungry-poet", "POLICY", "true"), fetch)
+		require.NoError(t, err)
+		assert.Equal(t, 3, value)
+		value, err = c.do(ctx, cacheKey(cacheKindFrameworks, "hungry-poet"), fetch)
+		require.NoError(t, err)
+		assert.Equal(t, 2, value)
+	})
+
+	t.Run("Waiters honor their context", func(t *testing.T) {
+		c := newQueryCache()
+		release := make(chan struct{})
+		started := make(chan struct{})
+		go func() {
+			_, _ = c.do(ctx, cacheKey(cacheKindSpace, "hungry-poet"), func() (interface{}, error) {
+				close(started)
+				<-release
+				return "hungry-poet", nil
+			})
+		}()
+		<-started
+
+		waitCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := c.do(waitCtx, cacheKey(cacheKindSpace, "hungry-poet"), func() (interface{}, error) {
+			t.Fatal("the query is already in flight")
+			return nil, nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		close(release)
+	})
+}
+
+func TestCachedQueryCreateAfterMiss(t *testing.T) {
+	ctx := context.Background()
+	c := &ExtendedGqlClient{cache: newQueryCache()}
+	spaces := map[string]spacePayload{}
+	getSpace := func(mrn string) (spacePayload, error) {
+		return cachedQuery(ctx, c, cacheKey(cacheKindSpace, mrn), func() (spacePayload, error) {
+			return spaces[mrn], nil
+		})
+	}
+
+	// a data source looks for the space before it is created, without any mutation of ours
+	// in between, e.g. it was created in the console or by another Terraform run
+	space, err := getSpace("//captain.api.mondoo.app/spaces/hungry-poet")
+	require.NoError(t, err)
+	assert.Empty(t, space.Mrn)
+
+	spaces["//captain.api.mondoo.app/spaces/hungry-poet"] = spacePayload{Id: "hungry-poet", Mrn: "//captain.api.mondoo.app/spaces/hungry-poet"}
+	space, err = getSpace("//captain.api.mondoo.app/spaces/hungry-poet")
+	require.NoError(t, err)
+	assert.Equal(t, "hungry-poet", space.Id)
+}
+
+func TestCachedQueryWithoutCache(t *testing.T) {
+	c := &ExtendedGqlClient{}
+	fetches := 0
+	for i := 0; i < 2; i++ {
+		value, err := cachedQuery(context.Background(), c, cacheKey(cacheKindSpace, "hungry-poet"), func() (string, error) {
+			fetches++
+			return "hungry-poet", nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "hungry-poet", value)
+	}
+	assert.Equal(t, 2, fetches)
+}
@@ -28,3 +28,30 @@ func ConvertSliceStrings(list types.List) (slice []mondoov1.String) {
 	allowlist.ElementsAs(ctx, &slice, true)
 	return
 }
+
+// RefreshStringValue returns the value read from the API, unless the prior value is null and
+// the API returned the zero value, in which case the prior value is kept to avoid a diff.
+func RefreshStringValue(prior types.String, value string) types.String {
+	if prior.IsNull() && value == "" {
+		return prior
+	}
+	return types.StringValue(value)
+}
+
+// RefreshBoolValue returns the value read from the API, unless the prior value is null and
+// the API returned the zero value, in which case the prior value is kept to avoid a diff.
+func RefreshBoolValue(prior types.Bool, value bool) types.Bool {
+	if prior.IsNull() && !value {
+		return prior
+	}
+	return types.BoolValue(value)
+}
+
+// RefreshListValue returns the list read from the API, unless the prior value is null and
+// the API returned an empty list, in which case the prior value is kept to avoid a diff.
+func RefreshListValue(prior types.List, list []string) types.List {
+	if prior.IsNull() && len(list) == 0 {
+		return prior
+	}
+	return ConvertListValue(list)
+}
@@ -132,3 +132,127 @@ func TestConvertSliceStrings(t *testing.T) {
 		})
 	}
 }
+
+func TestRefreshStringValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		prior    types.String
+		value    string
+		expected types.String
+	}{
+		{
+			name:     "Null prior with empty value",
+			prior:    types.StringNull(),
+			value:    "",
+			expected: types.StringNull(),
+		},
+		{
+			name:     "Null prior with value",
+			prior:    types.StringNull(),
+			value:    "hello",
+			expected: types.StringValue("hello"),
+		},
+		{
+			name:     "Known prior with changed value",
+			prior:    types.StringValue("hello"),
+			value:    "world",
+			expected: types.StringValue("world"),
+		},
+		{
+			name:     "Known prior with empty value",
+			prior:    types.StringValue("hello"),
+			value:    "",
+			expected: types.StringValue(""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RefreshStringValue(tt.prior, tt.value)
+			assert.Equal(t, tt.expected, result,
+				"Refreshed value should match expected output",
+			)
+		})
+	}
+}
+
+func TestRefreshBoolValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		prior    types.Bool
+		value    bool
+		expected types.Bool
+	}{
+		{
+			name:     "Null prior with false value",
+			prior:    types.BoolNull(),
+			value:    false,
+			expected: types.BoolNull(),
+		},
+		{
+			name:     "Null prior with true value",
+			prior:    types.BoolNull(),
+			value:    true,
+			expected: types.BoolValue(true),
+		},
+		{
+			name:     "Known prior with changed value",
+			prior:    types.BoolValue(true),
+			value:    false,
+			expected: types.BoolValue(false),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RefreshBoolValue(tt.prior, tt.value)
+			assert.Equal(t, tt.expected, result,
+				"Refreshed value should match expected output",
+			)
+		})
+	}
+}
+
+func TestRefreshListValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		prior    types.List
+		input    []string
+		expected types.List
+	}{
+		{
+			name:     "Null prior with empty list",
+			prior:    types.ListNull(types.StringType),
+			input:    []string{},
+			expected: types.ListNull(types.StringType),
+		},
+		{
+			name:  "Null prior with items",
+			prior: types.ListNull(types.StringType),
+			input: []string{"hello"},
+			expected: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue("hello"),
+			}),
+		},
+		{
+			name: "Known prior with changed items",
+			prior: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue("hello"),
+			}),
+			input: []string{"hello", "world"},
+			expected: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue("hello"),
+				types.StringValue("world"),
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RefreshListValue(tt.prior, tt.input)
+			assert.Equal(t, tt.expected, result,
+				"Refreshed list should match expected output",
+			)
+		})
+	}
+}
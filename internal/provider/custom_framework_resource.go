@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -180,7 +179,31 @@ func (r *customFrameworkResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	// Read API call logic
+	space, err := r.client.ComputeSpace(data.SpaceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
+		return
+	}
+	frameworkMrn, err := ParseMrn(data.Mrn.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid State", err.Error())
+		return
+	}
+
+	framework, err := r.client.GetFramework(ctx, space.MRN(), space.ID(), frameworkMrn.ID())
+	if err == nil && framework.Mrn == "" {
+		err = newNotFoundError("compliance framework", data.Mrn.ValueString())
+	}
+	if removeIfNotFound(ctx, err, resp) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.
+			AddError("Client Error",
+				fmt.Sprintf("Unable to get compliance framework. Got error: %s", err),
+			)
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -263,10 +286,20 @@ func (r *customFrameworkResource) Delete(ctx context.Context, req resource.Delet
 func (r *customFrameworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// resource.ImportStatePassthroughID(ctx, path.Root("mrn"), req, resp)
 	mrn := req.ID
-	splitMrn := strings.Split(mrn, "/")
-	spaceMrn := spacePrefix + splitMrn[len(splitMrn)-3]
-	spaceID := splitMrn[len(splitMrn)-3]
-	uid := splitMrn[len(splitMrn)-1]
+	parsed, err := ParseMrn(mrn)
+	if err == nil && parsed.Space() == "" {
+		err = fmt.Errorf("%q does not belong to a space", mrn)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected the MRN of a compliance framework. Got error: %s", err),
+		)
+		return
+	}
+	spaceMrn := parsed.Space().MRN()
+	spaceID := parsed.Space().ID()
+	uid := parsed.ID()
 
 	if r.client.Space().ID() != "" && r.client.Space().ID() != spaceID {
 		// The provider is configured to manage resources in a different space than the one the
@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"hash/crc32"
 	"os"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -224,6 +223,21 @@ func (r *customPolicyResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	// the policies might have been deleted outside of Terraform
+	space, err := r.client.ComputeSpace(data.SpaceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
+		return
+	}
+	policyMrns := []string{}
+	resp.Diagnostics.Append(data.Mrns.ElementsAs(ctx, &policyMrns, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !r.client.ReadPolicies(ctx, space.MRN(), policyMrns, resp) {
+		return
+	}
+
 	//  check if the local content has changed, if so, update the policy
 	policyBundleData, checksum, err := r.getContent(data)
 	if err != nil {
@@ -322,7 +336,18 @@ func (r *customPolicyResource) Delete(ctx context.Context, req resource.DeleteRe
 
 func (r *customPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	mrn := req.ID
-	spaceID := strings.Split(mrn, "/")[len(strings.Split(mrn, "/"))-3]
+	parsed, err := ParseMrn(mrn)
+	if err == nil && parsed.Space() == "" {
+		err = fmt.Errorf("%q does not belong to a space", mrn)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected the MRN of a policy. Got error: %s", err),
+		)
+		return
+	}
+	spaceID := parsed.Space().ID()
 	if r.client.Space().ID() != "" && r.client.Space().ID() != spaceID {
 		// The provider is configured to manage resources in a different space than the one the resource is
 		// currently configured, we won't allow that
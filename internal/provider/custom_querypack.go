@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -216,6 +215,21 @@ func (r *customQueryPackResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	// the policies might have been deleted outside of Terraform
+	space, err := r.client.ComputeSpace(data.SpaceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
+		return
+	}
+	policyMrns := []string{}
+	resp.Diagnostics.Append(data.Mrns.ElementsAs(ctx, &policyMrns, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !r.client.ReadPolicies(ctx, space.MRN(), policyMrns, resp) {
+		return
+	}
+
 	//  check if the local content has changed, if so, update the policy
 	policyBundleData, checksum, err := r.getContent(data)
 	if err != nil {
@@ -327,9 +341,19 @@ func (r *customQueryPackResource) Delete(ctx context.Context, req resource.Delet
 
 func (r *customQueryPackResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	mrn := req.ID
-	splitMrn := strings.Split(mrn, "/")
-	spaceMrn := spacePrefix + splitMrn[len(splitMrn)-3]
-	spaceID := splitMrn[len(splitMrn)-3]
+	parsed, err := ParseMrn(mrn)
+	if err == nil && parsed.Space() == "" {
+		err = fmt.Errorf("%q does not belong to a space", mrn)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected the MRN of a query pack. Got error: %s", err),
+		)
+		return
+	}
+	spaceMrn := parsed.Space().MRN()
+	spaceID := parsed.Space().ID()
 
 	if r.client.Space().ID() != "" && r.client.Space().ID() != spaceID {
 		// The provider is configured to manage resources in a different space than the one the
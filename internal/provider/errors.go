@@ -0,0 +1,133 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The kinds of errors returned by the Mondoo API, use errors.Is to check them, e.g.
+//
+//	if errors.Is(err, ErrNotFound) { ... }
+var (
+	ErrNotFound         = errors.New("not found")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrValidation       = errors.New("validation failed")
+	ErrConflict         = errors.New("conflict")
+)
+
+// apiErrorKinds maps the GraphQL error messages to the kind of error. The API forwards the
+// gRPC status of the backend services, e.g. "rpc error: code = NotFound desc = space not found",
+// so we look for the gRPC codes first, and for a few precise human readable messages. The
+// patterns must stay narrow, a message matching by accident could, for example, remove a
+// resource from the Terraform state. The order matters, the first match wins.
+var apiErrorKinds = []struct {
+	kind     error
+	patterns []*regexp.Regexp
+}{
+	{ErrNotFound, []*regexp.Regexp{
+		regexp.MustCompile(`code = notfound\b`),
+		// e.g. space "//captain.api.mondoo.app/spaces/gone" not found
+		regexp.MustCompile(`\b[a-z]+ "?//[^ "]+"? (not found|does not exist)\b`),
+	}},
+	{ErrPermissionDenied, []*regexp.Regexp{
+		regexp.MustCompile(`code = (permissiondenied|unauthenticated)\b`),
+		regexp.MustCompile(`\b(permission|access) denied\b`),
+	}},
+	{ErrConflict, []*regexp.Regexp{
+		regexp.MustCompile(`code = (alreadyexists|aborted)\b`),
+	}},
+	{ErrValidation, []*regexp.Regexp{
+		regexp.MustCompile(`code = (invalidargument|failedprecondition|outofrange)\b`),
+		regexp.MustCompile(`\binvalid argument\b`),
+	}},
+}
+
+// apiError is an error returned by the Mondoo API, classified into one of the kinds above.
+type apiError struct {
+	kind error
+	err  error
+}
+
+func (e *apiError) Error() string {
+	return e.err.Error()
+}
+
+func (e *apiError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// classifyAPIError wraps the error returned by a GraphQL request into an apiError, when we can
+// tell its kind from the HTTP status code or the GraphQL error message.
+//
+// A 404 status is never reported as ErrNotFound: it means that the endpoint is wrong, e.g. a
+// misconfigured proxy, not that the object is gone, and Read would remove every resource from
+// the state. Objects that do not exist are reported in the GraphQL errors of a 200 response,
+// or with an empty payload, see newNotFoundError.
+func classifyAPIError(err error, info *requestInfo) error {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	if info != nil {
+		if info.transportErr != nil {
+			return err
+		}
+		if info.statusCode != 0 && info.statusCode != http.StatusOK {
+			switch info.statusCode {
+			case http.StatusUnauthorized, http.StatusForbidden:
+				return &apiError{ErrPermissionDenied, err}
+			case http.StatusConflict:
+				return &apiError{ErrConflict, err}
+			case http.StatusBadRequest, http.StatusUnprocessableEntity:
+				return &apiError{ErrValidation, err}
+			}
+			// the GraphQL errors are only meaningful in a 200 response
+			return err
+		}
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, k := range apiErrorKinds {
+		for _, pattern := range k.patterns {
+			if pattern.MatchString(message) {
+				return &apiError{k.kind, err}
+			}
+		}
+	}
+	return err
+}
+
+// newNotFoundError returns the error used when the API answers a query with an empty object
+// instead of an error, for objects that do not exist.
+func newNotFoundError(kind string, id string) error {
+	return &apiError{ErrNotFound, fmt.Errorf("%s %q not found", kind, id)}
+}
+
+// isNotFoundError checks if the error returned by the API means that the requested object does not exist.
+func isNotFoundError(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// removeIfNotFound removes the resource from the Terraform state when the error means that the
+// object no longer exists, e.g. it was deleted from the Mondoo Console, Terraform then plans to
+// create it again. It returns true if the resource was removed, Read must stop there.
+func removeIfNotFound(ctx context.Context, err error, resp *resource.ReadResponse) bool {
+	if !isNotFoundError(err) {
+		return false
+	}
+	tflog.Warn(ctx, "object not found, removing it from the state", map[string]interface{}{
+		"error": err.Error(),
+	})
+	resp.State.RemoveResource(ctx)
+	return true
+}
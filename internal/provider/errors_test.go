@@ -0,0 +1,59 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyAPIError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		info *requestInfo
+		kind error
+	}{
+		{"gRPC not found", errors.New("rpc error: code = NotFound desc = space does not exist"), nil, ErrNotFound},
+		{"Not found message", errors.New(`space "//captain.api.mondoo.app/spaces/gone" not found`), &requestInfo{statusCode: http.StatusOK}, ErrNotFound},
+		{"Permission denied", errors.New("rpc error: code = PermissionDenied desc = request permission unauthorized"), nil, ErrPermissionDenied},
+		{"Access denied", errors.New("access denied"), nil, ErrPermissionDenied},
+		{"Already exists", errors.New("rpc error: code = AlreadyExists desc = space with id already exists"), nil, ErrConflict},
+		{"Invalid argument", errors.New("rpc error: code = InvalidArgument desc = name must not be empty"), nil, ErrValidation},
+		{"Status code wins", errors.New("something went wrong"), &requestInfo{statusCode: http.StatusForbidden}, ErrPermissionDenied},
+		// a wrong endpoint must not look like deleted objects
+		{"Status not found", errors.New("non-200 OK status code: 404 Not Found"), &requestInfo{statusCode: http.StatusNotFound}, nil},
+		{"Not found message in error page", errors.New(`space "//captain.api.mondoo.app/spaces/gone" not found`), &requestInfo{statusCode: http.StatusBadGateway}, nil},
+		{"Transport error", errors.New(`Post "https://proxy/": dial tcp: lookup proxy: no such host`), &requestInfo{transportErr: errors.New("no such host")}, nil},
+		// loose matches of unrelated messages
+		{"Unrelated not found", errors.New("query mondoo-linux-security-ssh not found in bundle"), nil, nil},
+		{"Unrelated invalid", errors.New("invalid memory address or nil pointer dereference"), nil, nil},
+		{"Unrelated required", errors.New("authentication required by upstream"), nil, nil},
+		{"Unrelated conflict", errors.New("resolving conflict in policy scoring"), nil, nil},
+		{"Unknown", errors.New("internal server error"), &requestInfo{statusCode: http.StatusInternalServerError}, nil},
+	}
+	kinds := []error{ErrNotFound, ErrPermissionDenied, ErrValidation, ErrConflict}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyAPIError(tt.err, tt.info)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.err.Error(), err.Error())
+			for _, kind := range kinds {
+				assert.Equal(t, kind == tt.kind, errors.Is(err, kind), "kind %q", kind)
+			}
+		})
+	}
+
+	assert.NoError(t, classifyAPIError(nil, nil))
+	// cancellations are not API errors, even when the message says so
+	canceled := fmt.Errorf("not found: %w", context.Canceled)
+	assert.Same(t, canceled, classifyAPIError(canceled, nil))
+	assert.True(t, isNotFoundError(newNotFoundError("space", "gone")))
+}
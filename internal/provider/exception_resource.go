@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -20,7 +24,11 @@ import (
 	mondoov1 "go.mondoo.com/mondoo-go"
 )
 
-var _ resource.Resource = (*exceptionResource)(nil)
+var (
+	_ resource.Resource                 = (*exceptionResource)(nil)
+	_ resource.ResourceWithImportState  = (*exceptionResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*exceptionResource)(nil)
+)
 
 func NewExceptionResource() resource.Resource {
 	return &exceptionResource{}
@@ -44,34 +52,65 @@ type exceptionResourceModel struct {
 	ValidUntil        types.String `tfsdk:"valid_until"`
 	Justification     types.String `tfsdk:"justification"`
 	Action            types.String `tfsdk:"action"`
-	CheckMrns         types.List   `tfsdk:"check_mrns"`
-	VulnerabilityMrns types.List   `tfsdk:"vulnerability_mrns"`
+	CheckMrns         types.Set    `tfsdk:"check_mrns"`
+	ControlMrns       types.Set    `tfsdk:"control_mrns"`
+	CveMrns           types.Set    `tfsdk:"cve_mrns"`
+	VulnerabilityMrns types.Set    `tfsdk:"vulnerability_mrns"`
+	ApplyToCves       types.Bool   `tfsdk:"apply_to_cves"`
+}
+
+// exceptionTargets are the checks, controls, CVEs and vulnerabilities an exception applies to.
+type exceptionTargets struct {
+	checks          []string
+	controls        []string
+	cves            []string
+	vulnerabilities []string
+}
+
+// subtract returns the targets that are not in the other targets.
+func (t exceptionTargets) subtract(other exceptionTargets) exceptionTargets {
+	return exceptionTargets{
+		checks:          subtract(t.checks, other.checks),
+		controls:        subtract(t.controls, other.controls),
+		cves:            subtract(t.cves, other.cves),
+		vulnerabilities: subtract(t.vulnerabilities, other.vulnerabilities),
+	}
+}
+
+// empty checks if there is no target at all.
+func (t exceptionTargets) empty() bool {
+	return len(t.checks) == 0 && len(t.controls) == 0 && len(t.cves) == 0 && len(t.vulnerabilities) == 0
 }
 
 func (r *exceptionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_exception"
 }
 
-func (r *exceptionResource) GetConfigurationOptions(ctx context.Context, data *exceptionResourceModel) (scopeMrn string, checks []string, vulnerabilities []string, validUntilStr string, err error) {
+func (r *exceptionResource) GetConfigurationOptions(ctx context.Context, data *exceptionResourceModel) (scopeMrn string, targets exceptionTargets, validUntilStr string, err error) {
 	// Extract ScopeMrn
 	scopeMrn = data.ScopeMrn.ValueString()
 	if scopeMrn == "" {
 		scopeMrn = r.client.space.MRN()
 	}
 
-	// Extract Checks and Vulnerabilities
-	checks = []string{}
-	data.CheckMrns.ElementsAs(ctx, &checks, false)
-
-	vulnerabilities = []string{}
-	data.VulnerabilityMrns.ElementsAs(ctx, &vulnerabilities, false)
+	// Extract Checks, Controls, CVEs and Vulnerabilities
+	targets = exceptionTargets{
+		checks:          []string{},
+		controls:        []string{},
+		cves:            []string{},
+		vulnerabilities: []string{},
+	}
+	data.CheckMrns.ElementsAs(ctx, &targets.checks, false)
+	data.ControlMrns.ElementsAs(ctx, &targets.controls, false)
+	data.CveMrns.ElementsAs(ctx, &targets.cves, false)
+	data.VulnerabilityMrns.ElementsAs(ctx, &targets.vulnerabilities, false)
 
 	// Format ValidUntil to RFC3339 if provided
 	validUntil := data.ValidUntil.ValueString()
 	if validUntil != "" {
 		year, month, day, parseErr := parseDate(validUntil)
 		if parseErr != nil {
-			return "", nil, nil, "", parseErr
+			return "", exceptionTargets{}, "", parseErr
 		}
 		now := time.Now().UTC() // Use UTC directly
 		validUntilStr = time.Date(
@@ -86,7 +125,37 @@ func (r *exceptionResource) GetConfigurationOptions(ctx context.Context, data *e
 		).Format(time.RFC3339Nano) // Use RFC3339Nano to include nanoseconds
 	}
 
-	return scopeMrn, checks, vulnerabilities, validUntilStr, nil
+	return scopeMrn, targets, validUntilStr, nil
+}
+
+// expiredSnooze checks if the exception snoozes the targets until a date that is not in the future,
+// such a snooze expires right away and Terraform would plan to set it again and again.
+func expiredSnooze(data exceptionResourceModel, now time.Time) bool {
+	if data.Action.ValueString() != string(mondoov1.ExceptionMutationActionSnooze) || data.ValidUntil.ValueString() == "" {
+		return false
+	}
+	validUntil, err := time.Parse("2006-01-02", data.ValidUntil.ValueString())
+	return err == nil && !validUntil.After(now.UTC().Truncate(24*time.Hour))
+}
+
+// addExpiredSnoozeError reports a snooze that expired, see expiredSnooze.
+func addExpiredSnoozeError(data exceptionResourceModel, diags *diag.Diagnostics) {
+	diags.AddAttributeError(
+		path.Root("valid_until"),
+		"Snooze Already Expired",
+		fmt.Sprintf("The exception snoozes the targets until %s, which is not in the future. "+
+			"Set `valid_until` to a new date to snooze them again, or change the `action`.", data.ValidUntil.ValueString()),
+	)
+}
+
+// applyException sets the exception for the targets in the scope.
+func (r *exceptionResource) applyException(ctx context.Context, scopeMrn string, action mondoov1.ExceptionMutationAction, targets exceptionTargets, justification *string, validUntil *string, applyToCves bool) error {
+	return r.client.ApplyException(ctx, scopeMrn, action, targets.checks, targets.controls, targets.cves, targets.vulnerabilities, justification, validUntil, &applyToCves)
+}
+
+// enableException removes the exception from the targets in the scope.
+func (r *exceptionResource) enableException(ctx context.Context, scopeMrn string, targets exceptionTargets) error {
+	return r.applyException(ctx, scopeMrn, mondoov1.ExceptionMutationActionEnable, targets, (*string)(mondoov1.NewStringPtr("")), (*string)(mondoov1.NewStringPtr("")), false)
 }
 
 // ValidUntilValidator ensures the "valid_until" attribute is only set when "action" is "SNOOZE".
@@ -125,8 +194,23 @@ func NewValidUntilValidator() validator.String {
 	return &ValidUntilValidator{}
 }
 
+// exceptionTargetsDescription and exceptionTargetsValidators are shared by the attributes listing
+// the targets of the exception, an exception applies to a single kind of target.
+const exceptionTargetsDescription = "Exactly one of `check_mrns`, `control_mrns`, `cve_mrns` and `vulnerability_mrns` must be set."
+
+var exceptionTargetsValidators = []validator.Set{
+	setvalidator.ExactlyOneOf(
+		path.MatchRoot("check_mrns"),
+		path.MatchRoot("control_mrns"),
+		path.MatchRoot("cve_mrns"),
+		path.MatchRoot("vulnerability_mrns"),
+	),
+}
+
 func (r *exceptionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// version 1: check_mrns and vulnerability_mrns are sets, see UpgradeState
+		Version:             1,
 		MarkdownDescription: `Set custom exceptions for a scope.`,
 		Attributes: map[string]schema.Attribute{
 			"scope_mrn": schema.StringAttribute{
@@ -138,7 +222,7 @@ func (r *exceptionResource) Schema(ctx context.Context, req resource.SchemaReque
 				},
 			},
 			"valid_until": schema.StringAttribute{
-				MarkdownDescription: "The date when the exception is no longer valid.",
+				MarkdownDescription: "The date when the exception is no longer valid, in the format `YYYY-MM-DD`. A snooze must end in the future.",
 				Optional:            true,
 				Validators: []validator.String{
 					stringvalidator.RegexMatches(regexp.MustCompile(`[1-9][0-9][0-9]{2}-([0][1-9]|[1][0-2])-([1-2][0-9]|[0][1-9]|[3][0-1])`), "Date must be in the format 'YYYY-MM-DD'"),
@@ -158,32 +242,105 @@ func (r *exceptionResource) Schema(ctx context.Context, req resource.SchemaReque
 					stringvalidator.OneOf("SNOOZE", "ENABLE", "DISABLE", "OUT_OF_SCOPE"),
 				},
 			},
-			"check_mrns": schema.ListAttribute{
-				MarkdownDescription: "List of check MRNs to set exceptions for. If set, `vulnerability_mrns` must not be set.",
+			"check_mrns": schema.SetAttribute{
+				MarkdownDescription: "Set of check MRNs to set exceptions for. " + exceptionTargetsDescription,
 				ElementType:         types.StringType,
 				Optional:            true,
-				Validators: []validator.List{
-					listvalidator.ConflictsWith(path.Expressions{
-						path.MatchRoot("vulnerability_mrns"),
-					}...),
-					listvalidator.ExactlyOneOf(path.MatchRoot("check_mrns"), path.MatchRoot("vulnerability_mrns")),
-				},
+				Validators:          exceptionTargetsValidators,
+			},
+			"control_mrns": schema.SetAttribute{
+				MarkdownDescription: "Set of compliance framework control MRNs to set exceptions for. " + exceptionTargetsDescription,
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          exceptionTargetsValidators,
 			},
-			"vulnerability_mrns": schema.ListAttribute{
-				MarkdownDescription: "List of vulnerability MRNs to set exceptions for. If set, `check_mrns` must not be set.",
+			"cve_mrns": schema.SetAttribute{
+				MarkdownDescription: "Set of CVE MRNs to set exceptions for. " + exceptionTargetsDescription,
 				ElementType:         types.StringType,
 				Optional:            true,
-				Validators: []validator.List{
-					listvalidator.ConflictsWith(path.Expressions{
-						path.MatchRoot("check_mrns"),
-					}...),
-					listvalidator.ExactlyOneOf(path.MatchRoot("check_mrns"), path.MatchRoot("vulnerability_mrns")),
+				Validators:          exceptionTargetsValidators,
+			},
+			"vulnerability_mrns": schema.SetAttribute{
+				MarkdownDescription: "Set of vulnerability MRNs to set exceptions for. " + exceptionTargetsDescription,
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          exceptionTargetsValidators,
+			},
+			"apply_to_cves": schema.BoolAttribute{
+				MarkdownDescription: "Also apply the exception to the CVEs of the vulnerabilities listed in `vulnerability_mrns`. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// exceptionResourceModelV0 is the state of an exception before version 1 of the schema.
+type exceptionResourceModelV0 struct {
+	ScopeMrn          types.String `tfsdk:"scope_mrn"`
+	ValidUntil        types.String `tfsdk:"valid_until"`
+	Justification     types.String `tfsdk:"justification"`
+	Action            types.String `tfsdk:"action"`
+	CheckMrns         types.List   `tfsdk:"check_mrns"`
+	VulnerabilityMrns types.List   `tfsdk:"vulnerability_mrns"`
+}
+
+func (r *exceptionResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// version 1 turned check_mrns and vulnerability_mrns into sets since the order of the
+		// targets never mattered, and added control_mrns, cve_mrns and apply_to_cves
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"scope_mrn":          schema.StringAttribute{Optional: true, Computed: true},
+					"valid_until":        schema.StringAttribute{Optional: true},
+					"justification":      schema.StringAttribute{Optional: true},
+					"action":             schema.StringAttribute{Optional: true, Computed: true},
+					"check_mrns":         schema.ListAttribute{ElementType: types.StringType, Optional: true},
+					"vulnerability_mrns": schema.ListAttribute{ElementType: types.StringType, Optional: true},
 				},
 			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior exceptionResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := exceptionResourceModel{
+					ScopeMrn:          prior.ScopeMrn,
+					ValidUntil:        prior.ValidUntil,
+					Justification:     prior.Justification,
+					Action:            prior.Action,
+					CheckMrns:         listToSet(ctx, prior.CheckMrns, &resp.Diagnostics),
+					ControlMrns:       types.SetNull(types.StringType),
+					CveMrns:           types.SetNull(types.StringType),
+					VulnerabilityMrns: listToSet(ctx, prior.VulnerabilityMrns, &resp.Diagnostics),
+					ApplyToCves:       types.BoolValue(false),
+				}
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
 		},
 	}
 }
 
+// listToSet converts a list of strings into a set, duplicates are dropped.
+func listToSet(ctx context.Context, list types.List, diags *diag.Diagnostics) types.Set {
+	if list.IsNull() {
+		return types.SetNull(types.StringType)
+	}
+	values := []string{}
+	diags.Append(list.ElementsAs(ctx, &values, false)...)
+	slices.Sort(values)
+	set, d := types.SetValueFrom(ctx, types.StringType, slices.Compact(values))
+	diags.Append(d...)
+	return set
+}
+
 func (r *exceptionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -214,7 +371,12 @@ func (r *exceptionResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	scopeMrn, checks, vulnerabilities, validUntilStr, err := r.GetConfigurationOptions(ctx, &data)
+	if expiredSnooze(data, time.Now()) {
+		addExpiredSnoozeError(data, &resp.Diagnostics)
+		return
+	}
+
+	scopeMrn, targets, validUntilStr, err := r.GetConfigurationOptions(ctx, &data)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
 		return
@@ -222,7 +384,7 @@ func (r *exceptionResource) Create(ctx context.Context, req resource.CreateReque
 
 	// disable existing exceptions
 	tflog.Debug(ctx, fmt.Sprintf("Creating exception for scope %s", data.ScopeMrn.ValueString()))
-	err = r.client.ApplyException(ctx, scopeMrn, mondoov1.ExceptionMutationActionEnable, checks, []string{}, []string{}, vulnerabilities, (*string)(mondoov1.NewStringPtr("")), (*string)(mondoov1.NewStringPtr("")), (*bool)(mondoov1.NewBooleanPtr(false)))
+	err = r.enableException(ctx, scopeMrn, targets)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to disable existing exception", err.Error())
 		return
@@ -230,7 +392,7 @@ func (r *exceptionResource) Create(ctx context.Context, req resource.CreateReque
 
 	// Create API call logic
 	tflog.Debug(ctx, fmt.Sprintf("Creating exception for scope %s", data.ScopeMrn.ValueString()))
-	err = r.client.ApplyException(ctx, scopeMrn, mondoov1.ExceptionMutationAction(data.Action.ValueString()), checks, []string{}, []string{}, vulnerabilities, data.Justification.ValueStringPointer(), &validUntilStr, (*bool)(mondoov1.NewBooleanPtr(false)))
+	err = r.applyException(ctx, scopeMrn, mondoov1.ExceptionMutationAction(data.Action.ValueString()), targets, data.Justification.ValueStringPointer(), &validUntilStr, data.ApplyToCves.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create exception", err.Error())
 		return
@@ -252,43 +414,83 @@ func (r *exceptionResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	// Read API call logic
+	// the exceptions are gone with the space
+	if scope, err := ParseMrn(data.ScopeMrn.ValueString()); err == nil && scope.IDs["spaces"] != "" {
+		if _, ok := r.client.ReadSpace(ctx, SpaceFrom(scope.IDs["spaces"]).MRN(), resp); !ok {
+			return
+		}
+	}
+
+	// reconcile with the exceptions actually set, they might have expired or
+	// been removed from the Mondoo Console
+	groups, err := r.client.GetExceptionGroups(ctx, data.ScopeMrn.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read exceptions. Got error: %s", err),
+		)
+		return
+	}
+	resp.Diagnostics.Append(reconcileException(ctx, &data, groups, time.Now())...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *exceptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data exceptionResourceModel
+	var data, prior exceptionResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, checks, vulnerabilities, validUntilStr, err := r.GetConfigurationOptions(ctx, &data)
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
+	if expiredSnooze(data, time.Now()) {
+		addExpiredSnoozeError(data, &resp.Diagnostics)
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Deleting exception for scope %s", data.ScopeMrn.ValueString()))
-	err = r.client.ApplyException(ctx, data.ScopeMrn.ValueString(), mondoov1.ExceptionMutationActionEnable, checks, []string{}, []string{}, vulnerabilities, (*string)(mondoov1.NewStringPtr("")), (*string)(mondoov1.NewStringPtr("")), (*bool)(mondoov1.NewBooleanPtr(false)))
+	_, targets, validUntilStr, err := r.GetConfigurationOptions(ctx, &data)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to disable existing exception", err.Error())
+		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
 		return
 	}
-
-	// Update API call logic
-	tflog.Debug(ctx, fmt.Sprintf("Creating exception for scope %s", data.ScopeMrn.ValueString()))
-	err = r.client.ApplyException(ctx, data.ScopeMrn.ValueString(), mondoov1.ExceptionMutationAction(data.Action.ValueString()), checks, []string{}, []string{}, vulnerabilities, data.Justification.ValueStringPointer(), &validUntilStr, (*bool)(mondoov1.NewBooleanPtr(false)))
+	_, priorTargets, _, err := r.GetConfigurationOptions(ctx, &prior)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update exception", err.Error())
+		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
 		return
 	}
 
+	// only apply the delta, re-applying everything would briefly enable the
+	// targets that stay excepted
+	added := targets.subtract(priorTargets)
+	if !data.Action.Equal(prior.Action) || !data.Justification.Equal(prior.Justification) ||
+		!data.ValidUntil.Equal(prior.ValidUntil) || !data.ApplyToCves.Equal(prior.ApplyToCves) {
+		// the exception itself changed, it applies to every target
+		added = targets
+	}
+	if !added.empty() {
+		tflog.Debug(ctx, fmt.Sprintf("Updating exception for scope %s", data.ScopeMrn.ValueString()))
+		err = r.applyException(ctx, data.ScopeMrn.ValueString(), mondoov1.ExceptionMutationAction(data.Action.ValueString()), added, data.Justification.ValueStringPointer(), &validUntilStr, data.ApplyToCves.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to update exception", err.Error())
+			return
+		}
+	}
+
+	// the targets no longer listed get enabled again
+	if removed := priorTargets.subtract(targets); !removed.empty() {
+		tflog.Debug(ctx, fmt.Sprintf("Removing exception for scope %s", data.ScopeMrn.ValueString()))
+		err = r.enableException(ctx, data.ScopeMrn.ValueString(), removed)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to remove exception", err.Error())
+			return
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -303,7 +505,7 @@ func (r *exceptionResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	_, checks, vulnerabilities, _, err := r.GetConfigurationOptions(ctx, &data)
+	_, targets, _, err := r.GetConfigurationOptions(ctx, &data)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
 		return
@@ -311,9 +513,209 @@ func (r *exceptionResource) Delete(ctx context.Context, req resource.DeleteReque
 
 	// Delete API call logic
 	tflog.Debug(ctx, fmt.Sprintf("Deleting exception for scope %s", data.ScopeMrn.ValueString()))
-	err = r.client.ApplyException(ctx, data.ScopeMrn.ValueString(), mondoov1.ExceptionMutationActionEnable, checks, []string{}, []string{}, vulnerabilities, (*string)(mondoov1.NewStringPtr("")), (*string)(mondoov1.NewStringPtr("")), (*bool)(mondoov1.NewBooleanPtr(false)))
+	err = r.enableException(ctx, data.ScopeMrn.ValueString(), targets)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to delete exception", err.Error())
 		return
 	}
 }
+
+func (r *exceptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scopeMrn, refs, ok := strings.Cut(req.ID, ",")
+	if !ok || scopeMrn == "" || refs == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected `<scope mrn>,<exception id>` or `<scope mrn>,<target mrn>[,<target mrn>...]`. Got: %q", req.ID),
+		)
+		return
+	}
+
+	groups, err := r.client.GetExceptionGroups(ctx, scopeMrn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read exceptions. Got error: %s", err),
+		)
+		return
+	}
+
+	data, err := importedException(ctx, scopeMrn, strings.Split(refs, ","), groups, time.Now())
+	if err != nil {
+		resp.Diagnostics.AddError("Import Error", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// importedException builds the exception imported from the exception groups of the scope. The
+// exception is referenced either by the ID of its group, all the targets of the group are then
+// imported, or by some of its target MRNs.
+func importedException(ctx context.Context, scopeMrn string, refs []string, groups []ExceptionGroup, now time.Time) (exceptionResourceModel, error) {
+	byID := len(refs) == 1 && !strings.HasPrefix(refs[0], "//")
+
+	var group *ExceptionGroup
+	for i := range groups {
+		if (byID && groups[i].Id == refs[0]) || (!byID && containsAny(exceptionGroupMrns(groups[i]), refs)) {
+			group = &groups[i]
+			break
+		}
+	}
+	if group == nil {
+		return exceptionResourceModel{}, fmt.Errorf("no exception %s found in scope %s", strings.Join(refs, ", "), scopeMrn)
+	}
+	if byID {
+		refs = exceptionGroupMrns(*group)
+	}
+
+	// every kind of target starts with all the MRNs, the reconciliation keeps the ones the group
+	// holds for that kind
+	targets, diags := types.SetValueFrom(ctx, types.StringType, refs)
+	data := exceptionResourceModel{
+		ScopeMrn:          types.StringValue(scopeMrn),
+		ValidUntil:        types.StringNull(),
+		Justification:     types.StringNull(),
+		Action:            types.StringNull(),
+		CheckMrns:         targets,
+		ControlMrns:       targets,
+		CveMrns:           targets,
+		VulnerabilityMrns: targets,
+		ApplyToCves:       types.BoolValue(group.ApplyToCves),
+	}
+	diags.Append(reconcileException(ctx, &data, groups, now)...)
+	if diags.HasError() {
+		return exceptionResourceModel{}, fmt.Errorf("unable to import exception: %v", diags.Errors())
+	}
+
+	kinds := []string{}
+	for _, target := range []struct {
+		attribute string
+		set       *types.Set
+	}{
+		{"check_mrns", &data.CheckMrns},
+		{"control_mrns", &data.ControlMrns},
+		{"cve_mrns", &data.CveMrns},
+		{"vulnerability_mrns", &data.VulnerabilityMrns},
+	} {
+		if len(target.set.Elements()) == 0 {
+			*target.set = types.SetNull(types.StringType)
+		} else {
+			kinds = append(kinds, target.attribute)
+		}
+	}
+	// an exception only holds one kind of targets
+	if len(kinds) > 1 {
+		return exceptionResourceModel{}, fmt.Errorf(
+			"exception %s holds several kinds of targets (%s), import the targets of each kind separately by their MRNs",
+			strings.Join(refs, ", "), strings.Join(kinds, ", "))
+	}
+	return data, nil
+}
+
+// exceptionGroupMrns returns all the targets of the exception group.
+func exceptionGroupMrns(group ExceptionGroup) []string {
+	return slices.Concat(group.QueryMrns, group.ControlMrns, group.CveMrns, group.AdvisoryMrns)
+}
+
+// reconcileException updates the exception with the exception groups found in its scope. The
+// targets that no longer have an exception are left out, and an expired snooze is reported as
+// `ENABLE`, so that Terraform plans to set the exception again.
+func reconcileException(ctx context.Context, data *exceptionResourceModel, groups []ExceptionGroup, now time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// the targets of the exception, with the targets of the group they are matched against
+	type target struct {
+		set   *types.Set
+		mrns  []string
+		group func(ExceptionGroup) []string
+	}
+	targets := []target{
+		{set: &data.CheckMrns, group: func(g ExceptionGroup) []string { return g.QueryMrns }},
+		{set: &data.ControlMrns, group: func(g ExceptionGroup) []string { return g.ControlMrns }},
+		{set: &data.CveMrns, group: func(g ExceptionGroup) []string { return g.CveMrns }},
+		{set: &data.VulnerabilityMrns, group: func(g ExceptionGroup) []string { return g.AdvisoryMrns }},
+	}
+	for i := range targets {
+		targets[i].mrns = []string{}
+		diags.Append(targets[i].set.ElementsAs(ctx, &targets[i].mrns, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	// the exception is the group holding the first target that has one, all
+	// targets are set at once, so they normally share the same group
+	var group *ExceptionGroup
+	for i := range groups {
+		if slices.ContainsFunc(targets, func(t target) bool {
+			return containsAny(t.group(groups[i]), t.mrns)
+		}) {
+			group = &groups[i]
+			break
+		}
+	}
+
+	if group == nil {
+		// without exception, the targets are enabled, which is what ENABLE exceptions are for
+		if data.Action.ValueString() != string(mondoov1.ExceptionMutationActionEnable) {
+			data.Action = types.StringValue(string(mondoov1.ExceptionMutationActionEnable))
+		}
+		return diags
+	}
+
+	data.Action = types.StringValue(group.Action)
+	data.ApplyToCves = types.BoolValue(group.ApplyToCves)
+	if group.Justification != "" || !data.Justification.IsNull() {
+		data.Justification = types.StringValue(group.Justification)
+	}
+	if validUntil, err := time.Parse(time.RFC3339, group.ValidUntil); err == nil {
+		data.ValidUntil = types.StringValue(validUntil.UTC().Format("2006-01-02"))
+		if group.Action == string(mondoov1.ExceptionMutationActionSnooze) && validUntil.Before(now) {
+			// the snooze expired, the targets are enabled again
+			data.Action = types.StringValue(string(mondoov1.ExceptionMutationActionEnable))
+		}
+	} else if !data.ValidUntil.IsNull() {
+		data.ValidUntil = types.StringNull()
+	}
+
+	for _, t := range targets {
+		if t.set.IsNull() {
+			continue
+		}
+		var d diag.Diagnostics
+		*t.set, d = types.SetValueFrom(ctx, types.StringType, intersect(t.mrns, t.group(*group)))
+		diags.Append(d...)
+	}
+	return diags
+}
+
+// containsAny checks if any of the values is in the list.
+func containsAny(list []string, values []string) bool {
+	for _, value := range values {
+		if slices.Contains(list, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// subtract returns the values that are not in the list, keeping the order of the values.
+func subtract(values []string, list []string) []string {
+	result := []string{}
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// intersect returns the values that are in the list, keeping the order of the values.
+func intersect(values []string, list []string) []string {
+	result := []string{}
+	for _, value := range values {
+		if slices.Contains(list, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,386 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mondoov1 "go.mondoo.com/mondoo-go"
+)
+
+const (
+	testCheckSSH   = "//policy.api.mondoo.app/queries/mondoo-linux-security-permissions-on-etcsshsshd_config-are-configured"
+	testCheckShell = "//policy.api.mondoo.app/queries/mondoo-linux-security-ensure-default-user-shell-timeout-is-configured"
+
+	testControl      = "//policy.api.mondoo.app/frameworks/cis-controls-8/controls/cis-controls-8.1.1"
+	testControlOther = "//policy.api.mondoo.app/frameworks/cis-controls-8/controls/cis-controls-8.1.2"
+)
+
+func TestReconcileException(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	snoozed := func() exceptionResourceModel {
+		checks, _ := types.SetValueFrom(ctx, types.StringType, []string{testCheckSSH, testCheckShell})
+		return exceptionResourceModel{
+			ScopeMrn:          types.StringValue("//captain.api.mondoo.app/spaces/hungry-poet-123456"),
+			ValidUntil:        types.StringValue("2025-12-31"),
+			Justification:     types.StringValue("accepted risk"),
+			Action:            types.StringValue("SNOOZE"),
+			CheckMrns:         checks,
+			ControlMrns:       types.SetNull(types.StringType),
+			CveMrns:           types.SetNull(types.StringType),
+			VulnerabilityMrns: types.SetNull(types.StringType),
+			ApplyToCves:       types.BoolValue(false),
+		}
+	}
+	checks := func(data exceptionResourceModel) []string {
+		mrns := []string{}
+		data.CheckMrns.ElementsAs(ctx, &mrns, false)
+		return mrns
+	}
+
+	t.Run("In sync", func(t *testing.T) {
+		data := snoozed()
+		diags := reconcileException(ctx, &data, []ExceptionGroup{{
+			Action:        "SNOOZE",
+			Justification: "accepted risk",
+			ValidUntil:    "2025-12-31T10:11:12Z",
+			QueryMrns:     []string{testCheckShell, testCheckSSH},
+		}}, now)
+		require.False(t, diags.HasError())
+		assert.Equal(t, snoozed(), data)
+	})
+
+	t.Run("Changed in the console", func(t *testing.T) {
+		data := snoozed()
+		diags := reconcileException(ctx, &data, []ExceptionGroup{{
+			Action:        "DISABLE",
+			Justification: "not applicable",
+			QueryMrns:     []string{testCheckShell},
+		}}, now)
+		require.False(t, diags.HasError())
+		assert.Equal(t, "DISABLE", data.Action.ValueString())
+		assert.Equal(t, "not applicable", data.Justification.ValueString())
+		assert.True(t, data.ValidUntil.IsNull())
+		assert.Equal(t, []string{testCheckShell}, checks(data))
+		assert.True(t, data.VulnerabilityMrns.IsNull())
+	})
+
+	t.Run("CVEs changed in the console", func(t *testing.T) {
+		data := snoozed()
+		data.CheckMrns = types.SetNull(types.StringType)
+		data.VulnerabilityMrns, _ = types.SetValueFrom(ctx, types.StringType, []string{"//vadvisor.api.mondoo.app/vulnerabilities/CVE-2024-6387"})
+		diags := reconcileException(ctx, &data, []ExceptionGroup{{
+			Action:        "SNOOZE",
+			Justification: "accepted risk",
+			ValidUntil:    "2025-12-31T10:11:12Z",
+			AdvisoryMrns:  []string{"//vadvisor.api.mondoo.app/vulnerabilities/CVE-2024-6387"},
+			ApplyToCves:   true,
+		}}, now)
+		require.False(t, diags.HasError())
+		assert.True(t, data.ApplyToCves.ValueBool())
+	})
+
+	t.Run("Expired snooze", func(t *testing.T) {
+		data := snoozed()
+		diags := reconcileException(ctx, &data, []ExceptionGroup{{
+			Action:        "SNOOZE",
+			Justification: "accepted risk",
+			ValidUntil:    "2025-05-31T10:11:12Z",
+			QueryMrns:     []string{testCheckSSH, testCheckShell},
+		}}, now)
+		require.False(t, diags.HasError())
+		assert.Equal(t, "ENABLE", data.Action.ValueString())
+		assert.Equal(t, "2025-05-31", data.ValidUntil.ValueString())
+	})
+
+	t.Run("Controls", func(t *testing.T) {
+		data := snoozed()
+		data.CheckMrns = types.SetNull(types.StringType)
+		data.ControlMrns, _ = types.SetValueFrom(ctx, types.StringType, []string{testControl, testControlOther})
+		diags := reconcileException(ctx, &data, []ExceptionGroup{
+			{Action: "DISABLE", QueryMrns: []string{testCheckSSH}},
+			{Action: "OUT_OF_SCOPE", ControlMrns: []string{testControl}},
+		}, now)
+		require.False(t, diags.HasError())
+		assert.Equal(t, "OUT_OF_SCOPE", data.Action.ValueString())
+		controls := []string{}
+		data.ControlMrns.ElementsAs(ctx, &controls, false)
+		assert.Equal(t, []string{testControl}, controls)
+		assert.True(t, data.CheckMrns.IsNull())
+	})
+
+	t.Run("Removed", func(t *testing.T) {
+		data := snoozed()
+		diags := reconcileException(ctx, &data, []ExceptionGroup{{
+			Action:       "DISABLE",
+			AdvisoryMrns: []string{"//vadvisor.api.mondoo.app/vulnerabilities/CVE-2024-6387"},
+		}}, now)
+		require.False(t, diags.HasError())
+		assert.Equal(t, "ENABLE", data.Action.ValueString())
+	})
+}
+
+func TestExpiredSnooze(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	exception := func(action string, validUntil string) exceptionResourceModel {
+		data := exceptionResourceModel{Action: types.StringValue(action), ValidUntil: types.StringNull()}
+		if validUntil != "" {
+			data.ValidUntil = types.StringValue(validUntil)
+		}
+		return data
+	}
+
+	assert.False(t, expiredSnooze(exception("SNOOZE", "2025-06-02"), now))
+	assert.False(t, expiredSnooze(exception("SNOOZE", ""), now))
+	assert.True(t, expiredSnooze(exception("SNOOZE", "2025-06-01"), now))
+	assert.True(t, expiredSnooze(exception("SNOOZE", "2025-05-31"), now))
+	assert.False(t, expiredSnooze(exception("DISABLE", "2025-05-31"), now))
+}
+
+func TestExceptionUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &exceptionResource{}
+	upgrader := r.UpgradeState(ctx)[0]
+
+	checks, _ := types.ListValueFrom(ctx, types.StringType, []string{testCheckSSH, testCheckShell, testCheckSSH})
+	priorState := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	require.False(t, priorState.Set(ctx, &exceptionResourceModelV0{
+		ScopeMrn:          types.StringValue("//captain.api.mondoo.app/spaces/hungry-poet-123456"),
+		ValidUntil:        types.StringNull(),
+		Justification:     types.StringValue("accepted risk"),
+		Action:            types.StringValue("DISABLE"),
+		CheckMrns:         checks,
+		VulnerabilityMrns: types.ListNull(types.StringType),
+	}).HasError())
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	resp := &fwresource.UpgradeStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{State: &priorState}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data exceptionResourceModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	expectedChecks, _ := types.SetValueFrom(ctx, types.StringType, []string{testCheckShell, testCheckSSH})
+	assert.Equal(t, exceptionResourceModel{
+		ScopeMrn:          types.StringValue("//captain.api.mondoo.app/spaces/hungry-poet-123456"),
+		ValidUntil:        types.StringNull(),
+		Justification:     types.StringValue("accepted risk"),
+		Action:            types.StringValue("DISABLE"),
+		CheckMrns:         expectedChecks,
+		ControlMrns:       types.SetNull(types.StringType),
+		CveMrns:           types.SetNull(types.StringType),
+		VulnerabilityMrns: types.SetNull(types.StringType),
+		ApplyToCves:       types.BoolValue(false),
+	}, data)
+}
+
+func TestSubtract(t *testing.T) {
+	assert.Equal(t, []string{testCheckShell}, subtract([]string{testCheckSSH, testCheckShell}, []string{testCheckSSH}))
+	assert.Equal(t, []string{}, subtract([]string{testCheckSSH}, []string{testCheckShell, testCheckSSH}))
+	assert.Equal(t, []string{testCheckSSH}, subtract([]string{testCheckSSH}, nil))
+
+	targets := exceptionTargets{checks: []string{}, controls: []string{testControl, testControlOther}}
+	removed := exceptionTargets{controls: []string{testControl}}.subtract(targets)
+	assert.True(t, removed.empty())
+	added := targets.subtract(exceptionTargets{controls: []string{testControl}})
+	assert.Equal(t, []string{testControlOther}, added.controls)
+	assert.False(t, added.empty())
+}
+
+func TestImportedException(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	scopeMrn := "//captain.api.mondoo.app/spaces/hungry-poet-123456"
+	groups := []ExceptionGroup{
+		{Id: "snooze", Action: "SNOOZE", Justification: "accepted risk", ValidUntil: "2025-12-31T10:11:12Z", QueryMrns: []string{testCheckSSH, testCheckShell}},
+		{Id: "scope", Action: "OUT_OF_SCOPE", ControlMrns: []string{testControl}},
+		{Id: "cves", Action: "DISABLE", AdvisoryMrns: []string{"//vadvisor.api.mondoo.app/advisories/DSA-5724-1"}, ApplyToCves: true},
+		{Id: "mixed", Action: "DISABLE", QueryMrns: []string{"//policy.api.mondoo.app/queries/mixed-check"}, ControlMrns: []string{"//policy.api.mondoo.app/frameworks/mixed/controls/mixed-control"}},
+	}
+	values := func(set types.Set) []string {
+		mrns := []string{}
+		set.ElementsAs(ctx, &mrns, false)
+		return mrns
+	}
+
+	t.Run("By ID", func(t *testing.T) {
+		data, err := importedException(ctx, scopeMrn, []string{"snooze"}, groups, now)
+		require.NoError(t, err)
+		assert.Equal(t, scopeMrn, data.ScopeMrn.ValueString())
+		assert.Equal(t, "SNOOZE", data.Action.ValueString())
+		assert.Equal(t, "accepted risk", data.Justification.ValueString())
+		assert.Equal(t, "2025-12-31", data.ValidUntil.ValueString())
+		assert.ElementsMatch(t, []string{testCheckSSH, testCheckShell}, values(data.CheckMrns))
+		assert.True(t, data.ControlMrns.IsNull())
+		assert.True(t, data.CveMrns.IsNull())
+		assert.True(t, data.VulnerabilityMrns.IsNull())
+		assert.False(t, data.ApplyToCves.ValueBool())
+	})
+
+	t.Run("By targets", func(t *testing.T) {
+		data, err := importedException(ctx, scopeMrn, []string{testControl}, groups, now)
+		require.NoError(t, err)
+		assert.Equal(t, "OUT_OF_SCOPE", data.Action.ValueString())
+		assert.True(t, data.Justification.IsNull())
+		assert.True(t, data.ValidUntil.IsNull())
+		assert.Equal(t, []string{testControl}, values(data.ControlMrns))
+		assert.True(t, data.CheckMrns.IsNull())
+
+		data, err = importedException(ctx, scopeMrn, []string{"//vadvisor.api.mondoo.app/advisories/DSA-5724-1"}, groups, now)
+		require.NoError(t, err)
+		assert.Equal(t, "DISABLE", data.Action.ValueString())
+		assert.True(t, data.ApplyToCves.ValueBool())
+	})
+
+	t.Run("Mixed kinds", func(t *testing.T) {
+		_, err := importedException(ctx, scopeMrn, []string{"mixed"}, groups, now)
+		assert.ErrorContains(t, err, "check_mrns, control_mrns")
+
+		// the targets of one kind can still be imported
+		data, err := importedException(ctx, scopeMrn, []string{"//policy.api.mondoo.app/queries/mixed-check"}, groups, now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"//policy.api.mondoo.app/queries/mixed-check"}, values(data.CheckMrns))
+		assert.True(t, data.ControlMrns.IsNull())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := importedException(ctx, scopeMrn, []string{"gone"}, groups, now)
+		assert.Error(t, err)
+		_, err = importedException(ctx, scopeMrn, []string{testControlOther}, groups, now)
+		assert.Error(t, err)
+	})
+}
+
+func TestAccExceptionResource(t *testing.T) {
+	var scopeMrn string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExceptionResourceConfig(accSpace.ID(), "2099-12-31"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mondoo_exception.test", "action", "SNOOZE"),
+					resource.TestCheckResourceAttr("mondoo_exception.test", "valid_until", "2099-12-31"),
+					resource.TestCheckResourceAttr("mondoo_exception.test", "check_mrns.#", "2"),
+					func(s *terraform.State) error {
+						scopeMrn = s.RootModule().Resources["mondoo_exception.test"].Primary.Attributes["scope_mrn"]
+						return nil
+					},
+				),
+			},
+			// one of the checks is enabled again from the Mondoo Console
+			{
+				PreConfig: func() {
+					client, err := mondooClient()
+					if err != nil {
+						t.Fatal(err)
+					}
+					extendedC := ExtendedGqlClient{Client: client, retry: defaultRetryConfig}
+					err = extendedC.ApplyException(context.Background(), scopeMrn, mondoov1.ExceptionMutationActionEnable,
+						[]string{testCheckShell}, nil, nil, nil, nil, nil, (*bool)(mondoov1.NewBooleanPtr(false)))
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccExceptionResourceConfig(accSpace.ID(), "2099-12-31"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mondoo_exception.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mondoo_exception.test", "check_mrns.#", "2"),
+			},
+			// ImportState testing
+			{
+				ResourceName: "mondoo_exception.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return scopeMrn + "," + testCheckSSH + "," + testCheckShell, nil
+				},
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "scope_mrn",
+			},
+		},
+	})
+}
+
+func TestAccExceptionResourceExpiredSnooze(t *testing.T) {
+	if fakeAPI == nil {
+		// only the fake API lets us travel forward in time
+		t.Skip("expiring a snooze requires the fake Mondoo API")
+	}
+
+	var scopeMrn string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExceptionResourceConfig(accSpace.ID(), "2099-12-31"),
+				Check: func(s *terraform.State) error {
+					scopeMrn = s.RootModule().Resources["mondoo_exception.test"].Primary.Attributes["scope_mrn"]
+					return nil
+				},
+			},
+			// the snooze expires, Terraform sets it again
+			{
+				PreConfig: func() {
+					fakeAPI.mu.Lock()
+					defer fakeAPI.mu.Unlock()
+					for _, group := range fakeAPI.exceptions[scopeMrn] {
+						group["validUntil"] = time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+					}
+				},
+				Config:             testAccExceptionResourceConfig(accSpace.ID(), "2099-12-31"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// snoozing until a date in the past would never converge
+			{
+				Config:      testAccExceptionResourceConfig(accSpace.ID(), "2020-01-01"),
+				ExpectError: regexp.MustCompile(`Snooze Already Expired`),
+			},
+		},
+	})
+}
+
+func testAccExceptionResourceConfig(spaceID string, validUntil string) string {
+	return fmt.Sprintf(`
+provider "mondoo" {
+  space = %[1]q
+}
+
+resource "mondoo_exception" "test" {
+  justification = "accepted risk"
+  action        = "SNOOZE"
+  valid_until   = %[2]q
+  check_mrns = [
+    %[3]q,
+    %[4]q,
+  ]
+}
+`, spaceID, validUntil, testCheckSSH, testCheckShell)
+}
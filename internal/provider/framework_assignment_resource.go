@@ -128,7 +128,15 @@ func (r *frameworkAssignmentResource) Read(ctx context.Context, req resource.Rea
 		return
 	}
 
-	// Read API call logic
+	// the assignments are gone with the space
+	space, err := r.client.ComputeSpace(data.SpaceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
+		return
+	}
+	if _, ok := r.client.ReadSpace(ctx, space.MRN(), resp); !ok {
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -8,7 +8,8 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
-	"strings"
+	"slices"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -27,6 +28,18 @@ type ExtendedGqlClient struct {
 	// The default space configured at the provider level, if configured, all resources
 	// will be managed there unless the resource itself specifies a different space
 	space Space
+
+	// How failed requests are retried, see Query and Mutate
+	retry retryConfig
+
+	// Who the provider is authenticated as
+	identity providerIdentity
+
+	// Memoized read-only queries, see cachedQuery
+	cache *queryCache
+
+	// Bounds the GraphQL requests in flight, see withRetries
+	limiter requestLimiter
 }
 
 // Space returns the space configured into the extended GraphQL client.
@@ -73,10 +86,6 @@ func (c *ExtendedGqlClient) CreateSpace(ctx context.Context, orgID string, id st
 		OrgMrn: mondoov1.String(orgPrefix + orgID),
 	}
 
-	tflog.Trace(ctx, "CreateSpaceInput", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", createInput),
-	})
-
 	err := c.Mutate(ctx, &createMutation, createInput, nil)
 	return createMutation.CreateSpace, err
 }
@@ -94,9 +103,6 @@ func (c *ExtendedGqlClient) UpdateSpace(ctx context.Context, spaceID string, nam
 		Mrn:  mondoov1.String(spacePrefix + spaceID),
 		Name: mondoov1.String(name),
 	}
-	tflog.Trace(ctx, "UpdateSpaceInput", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", updateInput),
-	})
 	return c.Mutate(ctx, &updateMutation, updateInput, nil)
 }
 
@@ -108,10 +114,6 @@ func (c *ExtendedGqlClient) DeleteSpace(ctx context.Context, spaceID string) err
 		"spaceMrn": mondoov1.ID(spacePrefix + spaceID),
 	}
 
-	tflog.Trace(ctx, "DeleteSpaceInput", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", variables),
-	})
-
 	return c.Mutate(ctx, &deleteMutation, nil, variables)
 }
 
@@ -123,19 +125,21 @@ type spacePayload struct {
 }
 
 func (c *ExtendedGqlClient) GetSpace(ctx context.Context, mrn string) (spacePayload, error) {
-	var q struct {
-		Space spacePayload `graphql:"space(mrn: $mrn)"`
-	}
-	variables := map[string]interface{}{
-		"mrn": mondoov1.String(mrn),
-	}
+	return cachedQuery(ctx, c, cacheKey(cacheKindSpace, mrn), func() (spacePayload, error) {
+		var q struct {
+			Space spacePayload `graphql:"space(mrn: $mrn)"`
+		}
+		variables := map[string]interface{}{
+			"mrn": mondoov1.String(mrn),
+		}
 
-	err := c.Query(ctx, &q, variables)
-	if err != nil {
-		return spacePayload{}, err
-	}
+		err := c.Query(ctx, &q, variables)
+		if err != nil {
+			return spacePayload{}, err
+		}
 
-	return q.Space, nil
+		return q.Space, nil
+	})
 }
 
 type orgPayload struct {
@@ -145,19 +149,21 @@ type orgPayload struct {
 }
 
 func (c *ExtendedGqlClient) GetOrganization(ctx context.Context, mrn string) (orgPayload, error) {
-	var q struct {
-		Organization orgPayload `graphql:"organization(mrn: $mrn)"`
-	}
-	variables := map[string]interface{}{
-		"mrn": mondoov1.String(mrn),
-	}
+	return cachedQuery(ctx, c, cacheKey(cacheKindOrganization, mrn), func() (orgPayload, error) {
+		var q struct {
+			Organization orgPayload `graphql:"organization(mrn: $mrn)"`
+		}
+		variables := map[string]interface{}{
+			"mrn": mondoov1.String(mrn),
+		}
 
-	err := c.Query(ctx, &q, variables)
-	if err != nil {
-		return orgPayload{}, err
-	}
+		err := c.Query(ctx, &q, variables)
+		if err != nil {
+			return orgPayload{}, err
+		}
 
-	return q.Organization, nil
+		return q.Organization, nil
+	})
 }
 
 type setCustomPolicyPayload struct {
@@ -239,10 +245,6 @@ func (c *ExtendedGqlClient) GetPolicySpaceReport(ctx context.Context, spaceMrn s
 		"input": input,
 	}
 
-	tflog.Trace(ctx, "GetSpaceReportInput", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", input),
-	})
-
 	// Execute the query
 	err := c.Query(ctx, &spaceReportQuery, variables)
 	if err != nil {
@@ -303,37 +305,42 @@ func (c *ExtendedGqlClient) DownloadBundle(ctx context.Context, policyMrn string
 }
 
 func (c *ExtendedGqlClient) GetPolicies(ctx context.Context, scopeMrn string, catalogType string, assignedOnly bool) (*[]Policy, error) {
-	// Define the query struct according to the provided query
-	var contentQuery struct {
-		Content Content `graphql:"content(input: $input)"`
-	}
-	// Define the input variable according to the provided query
-	input := mondoov1.ContentSearchInput{
-		ScopeMrn:     mondoov1.String(scopeMrn),
-		CatalogType:  mondoov1.CatalogType(catalogType),
-		AssignedOnly: mondoov1.NewBooleanPtr(mondoov1.Boolean(assignedOnly)),
-		Limit:        mondoov1.NewIntPtr(mondoov1.Int(10000)),
-	}
+	key := cacheKey(cacheKindPolicies, scopeMrn, catalogType, strconv.FormatBool(assignedOnly))
+	policies, err := cachedQuery(ctx, c, key, func() ([]Policy, error) {
+		// Define the query struct according to the provided query
+		var contentQuery struct {
+			Content Content `graphql:"content(input: $input)"`
+		}
+		// Define the input variable according to the provided query
+		input := mondoov1.ContentSearchInput{
+			ScopeMrn:     mondoov1.String(scopeMrn),
+			CatalogType:  mondoov1.CatalogType(catalogType),
+			AssignedOnly: mondoov1.NewBooleanPtr(mondoov1.Boolean(assignedOnly)),
+			Limit:        mondoov1.NewIntPtr(mondoov1.Int(10000)),
+		}
 
-	variables := map[string]interface{}{
-		"input": input,
-	}
+		variables := map[string]interface{}{
+			"input": input,
+		}
 
-	tflog.Trace(ctx, "GetContentInput", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", input),
-	})
+		// Execute the query
+		err := c.Query(ctx, &contentQuery, variables)
+		if err != nil {
+			return nil, err
+		}
 
-	// Execute the query
-	err := c.Query(ctx, &contentQuery, variables)
+		var policies []Policy
+		for _, edges := range contentQuery.Content.Edges {
+			policies = append(policies, edges.Node.Policy)
+		}
+		return policies, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var policies []Policy
-	for _, edges := range contentQuery.Content.Edges {
-		policies = append(policies, edges.Node.Policy)
-	}
-
+	// the cached slice is shared, callers get their own copy
+	policies = slices.Clone(policies)
 	return &policies, nil
 }
 
@@ -467,10 +474,6 @@ func (c *ExtendedGqlClient) CreateIntegration(ctx context.Context, spaceMrn, nam
 		ConfigurationOptions: opts,
 	}
 
-	tflog.Trace(ctx, "CreateSpaceInput", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", createInput),
-	})
-
 	err := c.Mutate(ctx, &createMutation, createInput, nil)
 	if err != nil {
 		return nil, err
@@ -502,11 +505,6 @@ func (c *ExtendedGqlClient) GetClientIntegrationToken(ctx context.Context, mrn s
 		"input": input,
 	}
 
-	// Trace the input variables for debugging
-	tflog.Trace(ctx, "GetClientIntegrationTokenInput", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", input),
-	})
-
 	// Perform the GraphQL query
 	err := c.Query(ctx, &query, variables)
 	if err != nil {
@@ -532,9 +530,6 @@ func (c *ExtendedGqlClient) UpdateIntegration(ctx context.Context, mrn, name str
 		Type:                 typ,
 		ConfigurationOptions: opts,
 	}
-	tflog.Trace(ctx, "UpdateClientIntegrationNameInput", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", updateInput),
-	})
 	err := c.Mutate(ctx, &updateMutation, updateInput, nil)
 	if err != nil {
 		return nil, err
@@ -553,9 +548,6 @@ func (c *ExtendedGqlClient) DeleteIntegration(ctx context.Context, mrn string) (
 	deleteInput := mondoov1.DeleteClientIntegrationInput{
 		Mrn: mondoov1.String(mrn),
 	}
-	tflog.Trace(ctx, "DeleteClientIntegration", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", deleteInput),
-	})
 	err := c.Mutate(ctx, &deleteMutation, deleteInput, nil)
 	if err != nil {
 		return nil, err
@@ -651,6 +643,19 @@ type EmailRecipient struct {
 	ReferenceURL string
 }
 
+type OciConfigurationOptions struct {
+	TenancyOcid string
+	Region      string
+	UserOcid    string
+	Fingerprint string
+}
+
+type AwsConfigurationOptions struct {
+	Region         string
+	IsOrganization bool
+	AccountIDs     []string `graphql:"accountIDs"`
+}
+
 type MicrosoftDefenderConfigurationOptionsInput struct {
 	TenantId               string
 	ClientId               string
@@ -672,6 +677,8 @@ type ClientIntegrationConfigurationOptions struct {
 	EmailConfigurationOptions                  EmailConfigurationOptions                  `graphql:"... on EmailConfigurationOptions"`
 	GitlabConfigurationOptions                 GitlabConfigurationOptions                 `graphql:"... on GitlabConfigurationOptions"`
 	MicrosoftDefenderConfigurationOptionsInput MicrosoftDefenderConfigurationOptionsInput `graphql:"... on MicrosoftDefenderConfigurationOptions"`
+	OciConfigurationOptions                    OciConfigurationOptions                    `graphql:"... on OciConfigurationOptions"`
+	AwsConfigurationOptions                    AwsConfigurationOptions                    `graphql:"... on AWSConfigurationOptions"`
 	// Add other configuration options here
 }
 
@@ -685,12 +692,11 @@ type Integration struct {
 func (i Integration) SpaceID() string {
 	// we are expecting MRNs like:
 	// => "//captain.api.mondoo.app/spaces/{ID}/integrations/{ID}"
-	mrnSplit := strings.Split(i.Mrn, "/")
-	l := len(mrnSplit)
-	if l >= 3 { // check for safety
-		return mrnSplit[l-3]
+	mrn, err := ParseMrn(i.Mrn)
+	if err != nil {
+		return ""
 	}
-	return ""
+	return mrn.Space().ID()
 }
 
 type ClientIntegration struct {
@@ -762,25 +768,183 @@ type AssetEdge struct {
 	Node   AssetNode
 }
 
+type PageInfo struct {
+	EndCursor   string
+	HasNextPage bool
+}
+
 type AssetsPayload struct {
 	TotalCount int
 	Edges      []AssetEdge
+	PageInfo   PageInfo
 }
 
-func (c *ExtendedGqlClient) GetAssets(ctx context.Context, spaceMrn string) (AssetsPayload, error) {
+const defaultAssetsPageSize = 100
+
+// GetAssets fetches the assets of a space following the page cursors until all assets are
+// fetched, or until maxResults assets are fetched if maxResults is greater than zero.
+func (c *ExtendedGqlClient) GetAssets(ctx context.Context, spaceMrn string, pageSize int, maxResults int) (AssetsPayload, error) {
 	var q struct {
-		Assets AssetsPayload `graphql:"assets(spaceMrn: $spaceMrn)"`
+		Assets AssetsPayload `graphql:"assets(spaceMrn: $spaceMrn, first: $first, after: $after)"`
+	}
+	if pageSize <= 0 {
+		pageSize = defaultAssetsPageSize
+	}
+
+	var (
+		assets AssetsPayload
+		after  *mondoov1.String
+	)
+	for {
+		first := pageSize
+		if maxResults > 0 {
+			first = min(pageSize, maxResults-len(assets.Edges))
+		}
+		variables := map[string]interface{}{
+			"spaceMrn": mondoov1.String(spaceMrn),
+			"first":    mondoov1.Int(first),
+			"after":    after,
+		}
+		tflog.Trace(ctx, "GetAssets", map[string]interface{}{
+			"page_size": first,
+			"fetched":   len(assets.Edges),
+		})
+
+		err := c.Query(ctx, &q, variables)
+		if err != nil {
+			return AssetsPayload{}, err
+		}
+
+		assets.TotalCount = q.Assets.TotalCount
+		assets.Edges = append(assets.Edges, q.Assets.Edges...)
+		if maxResults > 0 && len(assets.Edges) >= maxResults {
+			assets.Edges = assets.Edges[:maxResults]
+			break
+		}
+		if len(q.Assets.Edges) == 0 || !q.Assets.PageInfo.HasNextPage {
+			break
+		}
+
+		// continue after the last asset we got
+		cursor := q.Assets.Edges[len(q.Assets.Edges)-1].Cursor
+		if cursor == "" {
+			cursor = q.Assets.PageInfo.EndCursor
+		}
+		if cursor == "" {
+			// without a cursor we would fetch the same page over and over again
+			break
+		}
+		after = mondoov1.NewStringPtr(mondoov1.String(cursor))
+		q.Assets = AssetsPayload{}
+	}
+
+	return assets, nil
+}
+
+type createServiceAccountPayload struct {
+	Mrn         mondoov1.String
+	Certificate mondoov1.String
+	PrivateKey  mondoov1.String
+	ScopeMrn    mondoov1.String
+	ApiEndpoint mondoov1.String
+}
+
+func (c *ExtendedGqlClient) CreateServiceAccount(ctx context.Context, input mondoov1.CreateServiceAccountInput) (createServiceAccountPayload, error) {
+	var createMutation struct {
+		CreateServiceAccount createServiceAccountPayload `graphql:"createServiceAccount(input: $input)"`
+	}
+
+	err := c.Mutate(ctx, &createMutation, input, nil)
+	return createMutation.CreateServiceAccount, err
+}
+
+func (c *ExtendedGqlClient) DeleteServiceAccount(ctx context.Context, scopeMrn string, mrn string) error {
+	var deleteMutation struct {
+		DeleteServiceAccounts struct {
+			Mrns []mondoov1.String
+		} `graphql:"deleteServiceAccounts(input: $input)"`
+	}
+	deleteInput := mondoov1.DeleteServiceAccountsInput{
+		ScopeMrn: mondoov1.String(scopeMrn),
+		Mrns:     []mondoov1.String{mondoov1.String(mrn)},
+	}
+	return c.Mutate(ctx, &deleteMutation, deleteInput, nil)
+}
+
+type registrationTokenPayload struct {
+	Mrn         mondoov1.String
+	Description mondoov1.String
+	Token       mondoov1.String
+	Revoked     mondoov1.Boolean
+	ExpiresAt   mondoov1.String
+}
+
+func (c *ExtendedGqlClient) GenerateRegistrationToken(ctx context.Context, input mondoov1.RegistrationTokenInput) (registrationTokenPayload, error) {
+	var generateMutation struct {
+		RegistrationToken registrationTokenPayload `graphql:"generateRegistrationToken(input: $input)"`
+	}
+
+	err := c.Mutate(ctx, &generateMutation, input, nil)
+	return generateMutation.RegistrationToken, err
+}
+
+func (c *ExtendedGqlClient) RevokeRegistrationToken(ctx context.Context, mrn string) error {
+	var revokeMutation struct {
+		RevokeRegistrationTokenResponse struct {
+			RevokeRegistrationTokenSuccess struct {
+				Ok mondoov1.Boolean
+			} `graphql:"... on RevokeRegistrationTokenSuccess"`
+			RevokeRegistrationTokenFailure struct {
+				Message mondoov1.String
+				Code    mondoov1.String
+			} `graphql:"... on RevokeRegistrationTokenFailure"`
+		} `graphql:"revokeRegistrationToken(input: $input)"`
+	}
+
+	revokeInput := mondoov1.RevokeRegistrationTokenInput{
+		Mrn: mondoov1.String(mrn),
+	}
+	err := c.Mutate(ctx, &revokeMutation, revokeInput, nil)
+	if err != nil {
+		return err
+	}
+
+	failure := revokeMutation.RevokeRegistrationTokenResponse.RevokeRegistrationTokenFailure
+	if failure.Code == "NOT_FOUND" {
+		return newNotFoundError("registration token", mrn)
+	}
+	if failure.Message != "" {
+		return fmt.Errorf("%s (%s)", failure.Message, failure.Code)
+	}
+	return nil
+}
+
+// GetRegistrationToken returns the registration token, without the token itself, which is only
+// returned when it is generated.
+func (c *ExtendedGqlClient) GetRegistrationToken(ctx context.Context, mrn string) (registrationTokenPayload, error) {
+	var q struct {
+		RegistrationToken struct {
+			Mrn         mondoov1.String
+			Description mondoov1.String
+			Revoked     mondoov1.Boolean
+			ExpiresAt   mondoov1.String
+		} `graphql:"registrationToken(mrn: $mrn)"`
 	}
 	variables := map[string]interface{}{
-		"spaceMrn": mondoov1.String(spaceMrn),
+		"mrn": mondoov1.String(mrn),
 	}
 
 	err := c.Query(ctx, &q, variables)
 	if err != nil {
-		return AssetsPayload{}, err
+		return registrationTokenPayload{}, err
 	}
 
-	return q.Assets, nil
+	return registrationTokenPayload{
+		Mrn:         q.RegistrationToken.Mrn,
+		Description: q.RegistrationToken.Description,
+		Revoked:     q.RegistrationToken.Revoked,
+		ExpiresAt:   q.RegistrationToken.ExpiresAt,
+	}, nil
 }
 
 func (c *ExtendedGqlClient) SetScimGroupMapping(ctx context.Context, orgMrn string, group string, mappings []mondoov1.ScimGroupMapping) error {
@@ -799,6 +963,33 @@ func (c *ExtendedGqlClient) SetScimGroupMapping(ctx context.Context, orgMrn stri
 	return c.Mutate(ctx, &setScimGroupMappingMutation, setScimGroupMappingInput, nil)
 }
 
+// ScimGroupMappingPayload is the mapping of a SCIM 2.0 group to IAM roles in the organization or its spaces.
+type ScimGroupMappingPayload struct {
+	Group    string
+	Mappings []struct {
+		IamRole  string
+		SpaceMrn string
+		OrgMrn   string
+	}
+}
+
+// GetScimGroupMappings returns the SCIM 2.0 group mappings of the organization.
+func (c *ExtendedGqlClient) GetScimGroupMappings(ctx context.Context, orgMrn string) ([]ScimGroupMappingPayload, error) {
+	var q struct {
+		ScimGroupMappings []ScimGroupMappingPayload `graphql:"scimGroupMappings(input: {orgMrn: $orgMrn})"`
+	}
+	variables := map[string]interface{}{
+		"orgMrn": mondoov1.String(orgMrn),
+	}
+
+	err := c.Query(ctx, &q, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.ScimGroupMappings, nil
+}
+
 func (c *ExtendedGqlClient) UploadFramework(ctx context.Context, spaceMrn string, content []byte) error {
 	// Define the mutation struct according to the provided query
 	var uploadMutation struct {
@@ -884,25 +1075,30 @@ type GetComplianceFrameworksQuery struct {
 }
 
 func (c *ExtendedGqlClient) ListFrameworks(ctx context.Context, scopeMrn string) ([]ComplianceFrameworksPayload, error) {
-	// Define the query struct according to the provided query
-	var getFrameworksQuery GetComplianceFrameworksQuery
+	frameworks, err := cachedQuery(ctx, c, cacheKey(cacheKindFrameworks, scopeMrn), func() ([]ComplianceFrameworksPayload, error) {
+		// Define the query struct according to the provided query
+		var getFrameworksQuery GetComplianceFrameworksQuery
 
-	// Define the input variable according to the provided query
-	input := mondoov1.ComplianceFrameworksInput{
-		ScopeMrn: mondoov1.String(scopeMrn),
-	}
+		// Define the input variable according to the provided query
+		input := mondoov1.ComplianceFrameworksInput{
+			ScopeMrn: mondoov1.String(scopeMrn),
+		}
 
-	variables := map[string]interface{}{
-		"input": input,
-	}
+		variables := map[string]interface{}{
+			"input": input,
+		}
 
-	// Execute the query
-	err := c.Query(ctx, &getFrameworksQuery, variables)
-	if err != nil {
-		return nil, err
-	}
+		// Execute the query
+		err := c.Query(ctx, &getFrameworksQuery, variables)
+		if err != nil {
+			return nil, err
+		}
 
-	return getFrameworksQuery.ComplianceFrameworks, nil
+		return getFrameworksQuery.ComplianceFrameworks, nil
+	})
+
+	// the cached slice is shared, callers get their own copy
+	return slices.Clone(frameworks), err
 }
 
 func (c *ExtendedGqlClient) UpdateFramework(ctx context.Context, frameworkMrn string, scopeMrn string, enabled bool) error {
@@ -989,6 +1185,75 @@ func (c *ExtendedGqlClient) ImportIntegration(ctx context.Context, req resource.
 	return &integration, true
 }
 
+// ReadIntegration is a generic way to refresh an integration, this function fetches the integration from
+// the provided MRN and if it no longer exists, it removes the resource from the Terraform state.
+func (c *ExtendedGqlClient) ReadIntegration(ctx context.Context, mrn string, resp *resource.ReadResponse) (*Integration, bool) {
+	ctx = tflog.SetField(ctx, "mrn", mrn)
+	tflog.Debug(ctx, "reading integration")
+	integration, err := c.GetClientIntegration(ctx, mrn)
+	if err == nil && integration.Mrn == "" {
+		err = newNotFoundError("integration", mrn)
+	}
+	if removeIfNotFound(ctx, err, resp) {
+		return nil, false
+	}
+	if err != nil {
+		resp.Diagnostics.
+			AddError("Client Error",
+				fmt.Sprintf("Unable to read integration. Got error: %s", err),
+			)
+		return nil, false
+	}
+
+	return &integration, true
+}
+
+// ReadSpace fetches the space with the provided MRN, if it no longer exists, it removes the resource
+// from the Terraform state. Resources living in a space use it to detect that the space was deleted.
+func (c *ExtendedGqlClient) ReadSpace(ctx context.Context, spaceMrn string, resp *resource.ReadResponse) (*spacePayload, bool) {
+	ctx = tflog.SetField(ctx, "space_mrn", spaceMrn)
+	tflog.Debug(ctx, "reading space")
+	space, err := c.GetSpace(ctx, spaceMrn)
+	if err == nil && space.Mrn == "" {
+		err = newNotFoundError("space", spaceMrn)
+	}
+	if removeIfNotFound(ctx, err, resp) {
+		return nil, false
+	}
+	if err != nil {
+		resp.Diagnostics.
+			AddError("Client Error",
+				fmt.Sprintf("Unable to read space. Got error: %s", err),
+			)
+		return nil, false
+	}
+
+	return &space, true
+}
+
+// ReadPolicies checks that the policies uploaded by a resource still exist, if any of them was deleted,
+// it removes the resource from the Terraform state so that the whole bundle gets uploaded again.
+func (c *ExtendedGqlClient) ReadPolicies(ctx context.Context, spaceMrn string, policyMrns []string, resp *resource.ReadResponse) bool {
+	for _, policyMrn := range policyMrns {
+		tflog.Debug(ctx, "reading policy", map[string]interface{}{"policy_mrn": policyMrn})
+		policy, err := c.GetPolicy(ctx, policyMrn, spaceMrn)
+		if err == nil && policy.Mrn == "" {
+			err = newNotFoundError("policy", policyMrn)
+		}
+		if removeIfNotFound(ctx, err, resp) {
+			return false
+		}
+		if err != nil {
+			resp.Diagnostics.
+				AddError("Client Error",
+					fmt.Sprintf("Unable to read policy. Got error: %s", err),
+				)
+			return false
+		}
+	}
+	return true
+}
+
 func (c *ExtendedGqlClient) ApplyException(
 	ctx context.Context,
 	scopeMrn string,
@@ -1029,3 +1294,33 @@ func (c *ExtendedGqlClient) ApplyException(
 
 	return c.Mutate(ctx, &applyException, input, nil)
 }
+
+// ExceptionGroup is a set of checks, controls or vulnerabilities sharing the same exception.
+type ExceptionGroup struct {
+	Id            string
+	Action        string
+	Justification string
+	ValidUntil    string
+	QueryMrns     []string
+	ControlMrns   []string
+	CveMrns       []string
+	AdvisoryMrns  []string
+	ApplyToCves   bool
+}
+
+// GetExceptionGroups returns the exceptions set in the provided scope.
+func (c *ExtendedGqlClient) GetExceptionGroups(ctx context.Context, scopeMrn string) ([]ExceptionGroup, error) {
+	var q struct {
+		ExceptionGroups []ExceptionGroup `graphql:"exceptionGroups(input: {scopeMrn: $scopeMrn})"`
+	}
+	variables := map[string]interface{}{
+		"scopeMrn": mondoov1.String(scopeMrn),
+	}
+
+	err := c.Query(ctx, &q, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.ExceptionGroups, nil
+}
@@ -242,6 +242,20 @@ func (r *integrationAwsResource) Read(ctx context.Context, req resource.ReadRequ
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	// secrets cannot be read back, we only refresh the identifiers of the configured credential
+	opts := integration.ConfigurationOptions.HostedAwsConfigurationOptions
+	data.Name = types.StringValue(integration.Name)
+	if data.Credential.Role != nil && opts.Role != "" {
+		data.Credential.Role.RoleArn = types.StringValue(opts.Role)
+	}
+	if data.Credential.Key != nil && opts.AccessKeyId != "" {
+		data.Credential.Key.AccessKey = types.StringValue(opts.AccessKeyId)
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
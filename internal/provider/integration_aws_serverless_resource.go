@@ -481,6 +481,18 @@ func (r *integrationAwsServerlessResource) Read(ctx context.Context, req resourc
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	opts := integration.ConfigurationOptions.AwsConfigurationOptions
+	data.Name = types.StringValue(integration.Name)
+	if opts.Region != "" {
+		data.Region = types.StringValue(opts.Region)
+	}
+	data.IsOrganization = RefreshBoolValue(data.IsOrganization, opts.IsOrganization)
+	data.AccountIDs = RefreshListValue(data.AccountIDs, opts.AccountIDs)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -236,6 +236,18 @@ func (r *integrationAzureResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	opts := integration.ConfigurationOptions.AzureConfigurationOptions
+	data.Name = types.StringValue(integration.Name)
+	data.ClientId = types.StringValue(opts.ClientId)
+	data.TenantId = types.StringValue(opts.TenantId)
+	data.SubscriptionAllowList = RefreshListValue(data.SubscriptionAllowList, opts.SubscriptionsWhitelist)
+	data.SubscriptionDenyList = RefreshListValue(data.SubscriptionDenyList, opts.SubscriptionsBlacklist)
+	data.ScanVms = RefreshBoolValue(data.ScanVms, opts.ScanVms)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -173,6 +173,15 @@ func (r *integrationDomainResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	opts := integration.ConfigurationOptions.HostConfigurationOptions
+	data.Host = types.StringValue(opts.Host)
+	data.Https = RefreshBoolValue(data.Https, opts.HTTPS)
+	data.Http = RefreshBoolValue(data.Http, opts.HTTP)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
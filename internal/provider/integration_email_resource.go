@@ -362,6 +362,31 @@ func (r *integrationEmailResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	opts := integration.ConfigurationOptions.EmailConfigurationOptions
+	data.Name = types.StringValue(integration.Name)
+	data.AutoCreateTickets = RefreshBoolValue(data.AutoCreateTickets, opts.AutoCreateTickets)
+	data.AutoCloseTickets = RefreshBoolValue(data.AutoCloseTickets, opts.AutoCloseTickets)
+
+	recipients := []integrationEmailRecipientInput{}
+	for i, recipient := range opts.Recipients {
+		// use the prior recipient to keep optional attributes that were never set as null
+		prior := integrationEmailRecipientInput{IsDefault: types.BoolNull(), ReferenceURL: types.StringNull()}
+		if data.Recipients != nil && i < len(*data.Recipients) {
+			prior = (*data.Recipients)[i]
+		}
+		recipients = append(recipients, integrationEmailRecipientInput{
+			Name:         types.StringValue(recipient.Name),
+			Email:        types.StringValue(recipient.Email),
+			IsDefault:    RefreshBoolValue(prior.IsDefault, recipient.IsDefault),
+			ReferenceURL: RefreshStringValue(prior.ReferenceURL, recipient.ReferenceURL),
+		})
+	}
+	data.Recipients = &recipients
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
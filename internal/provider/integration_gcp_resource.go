@@ -182,6 +182,13 @@ func (r *integrationGcpResource) Read(ctx context.Context, req resource.ReadRequ
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	data.Name = types.StringValue(integration.Name)
+	data.ProjectID = RefreshStringValue(data.ProjectID, integration.ConfigurationOptions.GcpConfigurationOptions.ProjectId)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -250,6 +250,20 @@ func (r *integrationGithubResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	opts := integration.ConfigurationOptions.GithubConfigurationOptions
+	data.Name = types.StringValue(integration.Name)
+	data.Owner = types.StringValue(opts.Owner)
+	if opts.Owner == "" {
+		data.Owner = types.StringValue(opts.Organization)
+	}
+	data.Repository = RefreshStringValue(data.Repository, opts.Repository)
+	data.RepositoryAllowList = RefreshListValue(data.RepositoryAllowList, opts.ReposAllowList)
+	data.RepositoryDenyList = RefreshListValue(data.RepositoryDenyList, opts.ReposDenyList)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
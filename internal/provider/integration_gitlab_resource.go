@@ -244,6 +244,28 @@ func (r *integrationGitlabResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	opts := integration.ConfigurationOptions.GitlabConfigurationOptions
+	data.Name = types.StringValue(integration.Name)
+	data.Group = RefreshStringValue(data.Group, opts.Group)
+	data.BaseURL = RefreshStringValue(data.BaseURL, opts.BaseURL)
+	if data.Discovery != nil {
+		data.Discovery.Groups = RefreshBoolValue(data.Discovery.Groups, opts.DiscoverGroups)
+		data.Discovery.Projects = RefreshBoolValue(data.Discovery.Projects, opts.DiscoverProjects)
+		data.Discovery.Terraform = RefreshBoolValue(data.Discovery.Terraform, opts.DiscoverTerraform)
+		data.Discovery.K8sManifests = RefreshBoolValue(data.Discovery.K8sManifests, opts.DiscoverK8sManifests)
+	} else if opts.DiscoverGroups || opts.DiscoverProjects || opts.DiscoverTerraform || opts.DiscoverK8sManifests {
+		data.Discovery = &integrationGitlabDiscoveryModel{
+			Groups:       types.BoolValue(opts.DiscoverGroups),
+			Projects:     types.BoolValue(opts.DiscoverProjects),
+			Terraform:    types.BoolValue(opts.DiscoverTerraform),
+			K8sManifests: types.BoolValue(opts.DiscoverK8sManifests),
+		}
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -212,6 +212,18 @@ func (r *integrationJiraResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	opts := integration.ConfigurationOptions.JiraConfigurationOptions
+	data.Name = types.StringValue(integration.Name)
+	data.Host = types.StringValue(opts.Host)
+	data.Email = types.StringValue(opts.Email)
+	data.DefaultProject = RefreshStringValue(data.DefaultProject, opts.DefaultProject)
+	data.AutoCreate = RefreshBoolValue(data.AutoCreate, opts.AutoCreateCases)
+	data.AutoClose = RefreshBoolValue(data.AutoClose, opts.AutoCloseTickets)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -186,6 +185,14 @@ func (r *integrationMs365Resource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	data.Name = types.StringValue(integration.Name)
+	data.TenantId = types.StringValue(integration.ConfigurationOptions.Ms365ConfigurationOptions.TenantId)
+	data.ClientId = types.StringValue(integration.ConfigurationOptions.Ms365ConfigurationOptions.ClientId)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -260,7 +267,7 @@ func (r *integrationMs365Resource) ImportState(ctx context.Context, req resource
 		return
 	}
 
-	spaceID := strings.Split(integration.Mrn, "/")[len(strings.Split(integration.Mrn, "/"))-3]
+	spaceID := integration.SpaceID()
 	if r.client.Space().ID() != "" && r.client.Space().ID() != spaceID {
 		// The provider is configured to manage resources in a different space than the one the
 		// resource is currently configured, we won't allow that
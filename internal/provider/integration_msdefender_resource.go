@@ -227,6 +227,17 @@ func (r *integrationMsDefenderResource) Read(ctx context.Context, req resource.R
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	opts := integration.ConfigurationOptions.MicrosoftDefenderConfigurationOptionsInput
+	data.Name = types.StringValue(integration.Name)
+	data.ClientId = types.StringValue(opts.ClientId)
+	data.TenantId = types.StringValue(opts.TenantId)
+	data.SubscriptionAllowList = RefreshListValue(data.SubscriptionAllowList, opts.SubscriptionsAllowlist)
+	data.SubscriptionDenyList = RefreshListValue(data.SubscriptionDenyList, opts.SubscriptionsDenylist)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -6,7 +6,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -204,8 +203,20 @@ func (r *integrationOciTenantResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	// Write logs using the tflog package
-	tflog.Trace(ctx, "read an OCI integration resource")
+	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	opts := integration.ConfigurationOptions.OciConfigurationOptions
+	data.Name = types.StringValue(integration.Name)
+	data.Tenancy = types.StringValue(opts.TenancyOcid)
+	data.Region = types.StringValue(opts.Region)
+	data.User = types.StringValue(opts.UserOcid)
+	if opts.Fingerprint != "" {
+		data.Credential.Fingerprint = types.StringValue(opts.Fingerprint)
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -282,7 +293,7 @@ func (r *integrationOciTenantResource) ImportState(ctx context.Context, req reso
 		return
 	}
 
-	spaceID := strings.Split(integration.Mrn, "/")[len(strings.Split(integration.Mrn, "/"))-3]
+	spaceID := integration.SpaceID()
 	if r.client.Space().ID() != "" && r.client.Space().ID() != spaceID {
 		// The provider is configured to manage resources in a different space than the one the
 		// resource is currently configured, we won't allow that
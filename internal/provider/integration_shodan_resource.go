@@ -6,7 +6,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -204,6 +203,13 @@ func (r *integrationShodanResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	data.Name = types.StringValue(integration.Name)
+	data.Targets = RefreshListValue(data.Targets, integration.ConfigurationOptions.ShodanConfigurationOptions.Targets)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -284,7 +290,7 @@ func (r *integrationShodanResource) ImportState(ctx context.Context, req resourc
 		return
 	}
 
-	spaceID := strings.Split(integration.Mrn, "/")[len(strings.Split(integration.Mrn, "/"))-3]
+	spaceID := integration.SpaceID()
 	if r.client.Space().ID() != "" && r.client.Space().ID() != spaceID {
 		// The provider is configured to manage resources in a different space than the one the
 		// resource is currently configured, we won't allow that
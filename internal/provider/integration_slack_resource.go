@@ -171,6 +171,14 @@ func (r *integrationSlackResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	data.Name = types.StringValue(integration.Name)
+	// the Slack token is the only configuration option, the API never returns it, we keep the one
+	// from the state
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
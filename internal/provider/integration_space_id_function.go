@@ -0,0 +1,60 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &IntegrationSpaceIdFunction{}
+
+func NewIntegrationSpaceIdFunction() function.Function {
+	return &IntegrationSpaceIdFunction{}
+}
+
+// IntegrationSpaceIdFunction defines the function implementation.
+type IntegrationSpaceIdFunction struct{}
+
+func (f *IntegrationSpaceIdFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "integration_space_id"
+}
+
+func (f *IntegrationSpaceIdFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the id of the space of an integration",
+		MarkdownDescription: "Returns the unique identifier of the space an integration belongs to, from the Mondoo Resource Name (MRN) of the integration.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "integration_mrn",
+				MarkdownDescription: "The unique Mondoo Resource Name (MRN) of the integration.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *IntegrationSpaceIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var integrationMrn string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &integrationMrn))
+	if resp.Error != nil {
+		return
+	}
+
+	mrn, err := ParseMrn(integrationMrn)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	if mrn.Kind != "integrations" || mrn.IDs["spaces"] == "" {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%q is not the MRN of an integration", integrationMrn))
+		return
+	}
+
+	integration := Integration{Mrn: integrationMrn}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, integration.SpaceID()))
+}
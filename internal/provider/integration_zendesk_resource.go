@@ -238,6 +238,27 @@ func (r *integrationZendeskResource) Read(ctx context.Context, req resource.Read
 	}
 
 	// Read API call logic
+	integration, ok := r.client.ReadIntegration(ctx, data.Mrn.ValueString(), resp)
+	if !ok {
+		return
+	}
+
+	opts := integration.ConfigurationOptions.ZendeskConfigurationOptions
+	data.Name = types.StringValue(integration.Name)
+	data.Subdomain = types.StringValue(opts.Subdomain)
+	data.Email = types.StringValue(opts.Email)
+	data.AutoClose = RefreshBoolValue(data.AutoClose, opts.AutoCloseTickets)
+	data.AutoCreate = RefreshBoolValue(data.AutoCreate, opts.AutoCreateTickets)
+	if data.CustomFields != nil || len(opts.CustomFields) > 0 {
+		customFields := []integrationZendeskCustomFieldModel{}
+		for _, field := range opts.CustomFields {
+			customFields = append(customFields, integrationZendeskCustomFieldModel{
+				ID:    types.Int64Value(field.ID),
+				Value: types.StringValue(field.Value),
+			})
+		}
+		data.CustomFields = &customFields
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -0,0 +1,38 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import "context"
+
+// requestLimiter bounds the number of GraphQL requests in flight across all resources and data
+// sources sharing the client. A nil limiter doesn't limit anything.
+type requestLimiter chan struct{}
+
+// newRequestLimiter returns a limiter allowing max requests in flight, zero means no limit.
+func newRequestLimiter(max int) requestLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return make(requestLimiter, max)
+}
+
+// acquire waits for a free slot, or until the context is done.
+func (l requestLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees the slot taken by acquire.
+func (l requestLimiter) release() {
+	if l != nil {
+		<-l
+	}
+}
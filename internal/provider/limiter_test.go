@@ -0,0 +1,66 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLimiter(t *testing.T) {
+	t.Run("Bounds requests in flight", func(t *testing.T) {
+		client := &ExtendedGqlClient{limiter: newRequestLimiter(2)}
+		var inFlight, maxInFlight atomic.Int32
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := client.withRetries(context.Background(), true, func(ctx context.Context) error {
+					current := inFlight.Add(1)
+					defer inFlight.Add(-1)
+					for {
+						seen := maxInFlight.Load()
+						if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+							break
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+					return nil
+				})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(2), maxInFlight.Load())
+	})
+
+	t.Run("Queued requests honor cancellation", func(t *testing.T) {
+		limiter := newRequestLimiter(1)
+		require.NoError(t, limiter.acquire(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, limiter.acquire(ctx), context.DeadlineExceeded)
+
+		limiter.release()
+		assert.NoError(t, limiter.acquire(context.Background()))
+	})
+
+	t.Run("No limit", func(t *testing.T) {
+		limiter := newRequestLimiter(0)
+		assert.Nil(t, limiter)
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, limiter.acquire(context.Background()))
+		}
+		limiter.release()
+	})
+}
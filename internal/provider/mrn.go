@@ -0,0 +1,58 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mrn is a parsed Mondoo Resource Name (MRN), MRNs have the form:
+// => "//{service}/{collection}/{ID}[/{collection}/{ID}...]"
+type Mrn struct {
+	// Service is the API service owning the resource, e.g. "captain.api.mondoo.app"
+	Service string
+	// Kind is the collection of the resource, e.g. "integrations"
+	Kind string
+	// IDs contains the id of every collection found in the MRN, e.g. {"spaces": "abc", "integrations": "xyz"}
+	IDs map[string]string
+}
+
+// ID returns the id of the resource itself.
+func (m Mrn) ID() string {
+	return m.IDs[m.Kind]
+}
+
+// Space returns the space the resource belongs to, it is empty if the resource is not in a space.
+func (m Mrn) Space() Space {
+	return Space(m.IDs["spaces"])
+}
+
+// ParseMrn parses a Mondoo Resource Name.
+func ParseMrn(mrn string) (Mrn, error) {
+	if !strings.HasPrefix(mrn, "//") {
+		return Mrn{}, fmt.Errorf("invalid MRN %q, it must start with '//'", mrn)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(mrn, "//"), "/")
+	// we need the service and, at least, one pair of collection and id
+	if len(parts) < 3 || len(parts)%2 == 0 {
+		return Mrn{}, fmt.Errorf("invalid MRN %q, expected //{service}/{collection}/{ID}", mrn)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return Mrn{}, fmt.Errorf("invalid MRN %q, it contains empty segments", mrn)
+		}
+	}
+
+	parsed := Mrn{
+		Service: parts[0],
+		Kind:    parts[len(parts)-2],
+		IDs:     map[string]string{},
+	}
+	for i := 1; i < len(parts); i += 2 {
+		parsed.IDs[parts[i]] = parts[i+1]
+	}
+	return parsed, nil
+}
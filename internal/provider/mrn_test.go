@@ -0,0 +1,203 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMrn(t *testing.T) {
+	tests := []struct {
+		name     string
+		mrn      string
+		expected Mrn
+		wantErr  bool
+	}{
+		{
+			name: "Space",
+			mrn:  "//captain.api.mondoo.app/spaces/hungry-poet-123456",
+			expected: Mrn{
+				Service: "captain.api.mondoo.app",
+				Kind:    "spaces",
+				IDs:     map[string]string{"spaces": "hungry-poet-123456"},
+			},
+		},
+		{
+			name: "Integration",
+			mrn:  "//captain.api.mondoo.app/spaces/hungry-poet-123456/integrations/2Abd3VXhNqnQk7Uw2sq0z7BfDwh",
+			expected: Mrn{
+				Service: "captain.api.mondoo.app",
+				Kind:    "integrations",
+				IDs:     map[string]string{"spaces": "hungry-poet-123456", "integrations": "2Abd3VXhNqnQk7Uw2sq0z7BfDwh"},
+			},
+		},
+		{
+			name: "Public policy",
+			mrn:  "//policy.api.mondoo.app/policies/mondoo-aws-security",
+			expected: Mrn{
+				Service: "policy.api.mondoo.app",
+				Kind:    "policies",
+				IDs:     map[string]string{"policies": "mondoo-aws-security"},
+			},
+		},
+		{
+			name:    "Missing prefix",
+			mrn:     "captain.api.mondoo.app/spaces/hungry-poet-123456",
+			wantErr: true,
+		},
+		{
+			name:    "Missing id",
+			mrn:     "//captain.api.mondoo.app/spaces/hungry-poet-123456/integrations",
+			wantErr: true,
+		},
+		{
+			name:    "Empty segment",
+			mrn:     "//captain.api.mondoo.app/spaces//integrations/abc",
+			wantErr: true,
+		},
+		{
+			name:    "Space id",
+			mrn:     "hungry-poet-123456",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mrn, err := ParseMrn(tt.mrn)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, mrn)
+		})
+	}
+}
+
+func TestIntegrationSpaceID(t *testing.T) {
+	integration := Integration{Mrn: "//captain.api.mondoo.app/spaces/hungry-poet-123456/integrations/2Abd3VXhNqnQk7Uw2sq0z7BfDwh"}
+	assert.Equal(t, "hungry-poet-123456", integration.SpaceID())
+
+	// the integration MRN is not trusted to be well-formed
+	assert.Equal(t, "", Integration{Mrn: "2Abd3VXhNqnQk7Uw2sq0z7BfDwh"}.SpaceID())
+	assert.Equal(t, "", Integration{Mrn: "//captain.api.mondoo.app/integrations/2Abd3VXhNqnQk7Uw2sq0z7BfDwh"}.SpaceID())
+}
+
+func TestMrnFunctions(t *testing.T) {
+	integrationMrn := "//captain.api.mondoo.app/spaces/hungry-poet-123456/integrations/2Abd3VXhNqnQk7Uw2sq0z7BfDwh"
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		err      string
+	}{
+		{
+			name:     "space_mrn",
+			value:    `provider::mondoo::space_mrn("hungry-poet-123456")`,
+			expected: "//captain.api.mondoo.app/spaces/hungry-poet-123456",
+		},
+		{
+			name:  "space_mrn with an MRN",
+			value: `provider::mondoo::space_mrn("//captain.api.mondoo.app/spaces/hungry-poet-123456")`,
+			err:   `Invalid space id`,
+		},
+		{
+			name:  "space_mrn without id",
+			value: `provider::mondoo::space_mrn("")`,
+			err:   `Invalid space id`,
+		},
+		{
+			name:     "org_mrn",
+			value:    `provider::mondoo::org_mrn("lunalectric")`,
+			expected: "//captain.api.mondoo.app/organizations/lunalectric",
+		},
+		{
+			name:  "org_mrn without id",
+			value: `provider::mondoo::org_mrn("")`,
+			err:   `Invalid organization id`,
+		},
+		{
+			name:     "space_id",
+			value:    `provider::mondoo::space_id("//captain.api.mondoo.app/spaces/hungry-poet-123456")`,
+			expected: "hungry-poet-123456",
+		},
+		{
+			name:  "space_id of an integration",
+			value: `provider::mondoo::space_id("//captain.api.mondoo.app/spaces/hungry-poet-123456/integrations/abc")`,
+			err:   `is not the MRN of a space`,
+		},
+		{
+			name:  "space_id of a malformed MRN",
+			value: `provider::mondoo::space_id("captain.api.mondoo.app/spaces/hungry-poet-123456")`,
+			err:   `invalid MRN`,
+		},
+		{
+			name:     "integration_space_id",
+			value:    fmt.Sprintf(`provider::mondoo::integration_space_id(%q)`, integrationMrn),
+			expected: "hungry-poet-123456",
+		},
+		{
+			name:  "integration_space_id of a space",
+			value: `provider::mondoo::integration_space_id("//captain.api.mondoo.app/spaces/hungry-poet-123456")`,
+			err:   `is not the MRN of an integration`,
+		},
+		{
+			name:  "integration_space_id of a malformed MRN",
+			value: `provider::mondoo::integration_space_id("//captain.api.mondoo.app/spaces//integrations/abc")`,
+			err:   `invalid MRN`,
+		},
+		{
+			name:     "parse_mrn service",
+			value:    fmt.Sprintf(`provider::mondoo::parse_mrn(%q).service`, integrationMrn),
+			expected: "captain.api.mondoo.app",
+		},
+		{
+			name:     "parse_mrn kind",
+			value:    fmt.Sprintf(`provider::mondoo::parse_mrn(%q).kind`, integrationMrn),
+			expected: "integrations",
+		},
+		{
+			name:     "parse_mrn ids",
+			value:    fmt.Sprintf(`provider::mondoo::parse_mrn(%q).ids["spaces"]`, integrationMrn),
+			expected: "hungry-poet-123456",
+		},
+		{
+			name:  "parse_mrn of an id",
+			value: `provider::mondoo::parse_mrn("hungry-poet-123456")`,
+			err:   `invalid MRN`,
+		},
+		{
+			name:  "parse_mrn without id",
+			value: `provider::mondoo::parse_mrn("//captain.api.mondoo.app/spaces/hungry-poet-123456/integrations")`,
+			err:   `invalid MRN`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := resource.TestStep{
+				Config: fmt.Sprintf("output \"test\" {\n  value = %s\n}\n", tt.value),
+			}
+			if tt.err != "" {
+				step.ExpectError = regexp.MustCompile(tt.err)
+			} else {
+				step.Check = resource.TestCheckOutput("test", tt.expected)
+			}
+			resource.Test(t, resource.TestCase{
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
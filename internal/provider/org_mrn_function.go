@@ -0,0 +1,54 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &OrgMrnFunction{}
+
+func NewOrgMrnFunction() function.Function {
+	return &OrgMrnFunction{}
+}
+
+// OrgMrnFunction defines the function implementation.
+type OrgMrnFunction struct{}
+
+func (f *OrgMrnFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "org_mrn"
+}
+
+func (f *OrgMrnFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the MRN of an organization",
+		MarkdownDescription: "Returns the Mondoo Resource Name (MRN) of the organization with the given id.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "org_id",
+				MarkdownDescription: "The unique identifier of the organization.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *OrgMrnFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var orgID string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &orgID))
+	if resp.Error != nil {
+		return
+	}
+
+	if orgID == "" || strings.Contains(orgID, "/") {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid organization id "+orgID)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, orgPrefix+orgID))
+}
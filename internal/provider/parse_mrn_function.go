@@ -0,0 +1,87 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ParseMrnFunction{}
+
+var parsedMrnAttrTypes = map[string]attr.Type{
+	"service": types.StringType,
+	"kind":    types.StringType,
+	"ids":     types.MapType{ElemType: types.StringType},
+}
+
+func NewParseMrnFunction() function.Function {
+	return &ParseMrnFunction{}
+}
+
+// ParseMrnFunction defines the function implementation.
+type ParseMrnFunction struct{}
+
+// parsedMrnModel describes the object returned by the function.
+type parsedMrnModel struct {
+	Service types.String            `tfsdk:"service"`
+	Kind    types.String            `tfsdk:"kind"`
+	IDs     map[string]types.String `tfsdk:"ids"`
+}
+
+func (f *ParseMrnFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_mrn"
+}
+
+func (f *ParseMrnFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses a Mondoo Resource Name",
+		MarkdownDescription: "Parses a Mondoo Resource Name (MRN) and returns an object with the `service` owning the resource, " +
+			"the `kind` of the resource, e.g. `integrations`, and the `ids` of every collection in the MRN, e.g. " +
+			"`{ spaces = \"abc\", integrations = \"xyz\" }`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "mrn",
+				MarkdownDescription: "The Mondoo Resource Name (MRN) to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parsedMrnAttrTypes,
+		},
+	}
+}
+
+func (f *ParseMrnFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	mrn, err := ParseMrn(input)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	model := parsedMrnModel{
+		Service: types.StringValue(mrn.Service),
+		Kind:    types.StringValue(mrn.Kind),
+		IDs:     map[string]types.String{},
+	}
+	for collection, id := range mrn.IDs {
+		model.IDs[collection] = types.StringValue(id)
+	}
+
+	result, diags := types.ObjectValueFrom(ctx, parsedMrnAttrTypes, model)
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
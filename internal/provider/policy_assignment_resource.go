@@ -6,8 +6,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"sort"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -20,7 +28,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ resource.Resource = (*policyAssignmentResource)(nil)
+var (
+	_ resource.Resource                = (*policyAssignmentResource)(nil)
+	_ resource.ResourceWithImportState = (*policyAssignmentResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*policyAssignmentResource)(nil)
+)
 
 func NewPolicyAssigmentResource() resource.Resource {
 	return &policyAssignmentResource{}
@@ -39,6 +51,12 @@ type policyAssigmentsResourceModel struct {
 
 	// state
 	State types.String `tfsdk:"state"`
+
+	// per-policy state, an alternative to policies and state
+	Assignments types.Map `tfsdk:"assignments"`
+
+	// unassign the policies that are not listed
+	Authoritative types.Bool `tfsdk:"authoritative"`
 }
 
 func (r *policyAssignmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,6 +78,9 @@ func (r *policyAssignmentResource) Schema(_ context.Context, req resource.Schema
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
 				},
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("assignments")),
+				},
 			},
 			"state": schema.StringAttribute{
 				MarkdownDescription: "Policy assignment state (preview, enabled, or disabled).",
@@ -70,10 +91,42 @@ func (r *policyAssignmentResource) Schema(_ context.Context, req resource.Schema
 					stringvalidator.OneOf("enabled", "disabled", "preview"),
 				},
 			},
+			"assignments": schema.MapAttribute{
+				MarkdownDescription: "Policies to assign to the space, mapped to their assignment state (preview, enabled, or disabled). " +
+					"An alternative to `policies` and `state` to mix policy states in a single resource.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.OneOf("enabled", "disabled", "preview")),
+					mapvalidator.ConflictsWith(path.MatchRoot("state")),
+				},
+			},
+			"authoritative": schema.BoolAttribute{
+				MarkdownDescription: "Unassign every other policy assigned to the space, so that the space policies are fully described by this resource. " +
+					"Use a single authoritative policy assignment per space. Defaults to `false`.",
+				Default:  booldefault.StaticBool(false),
+				Computed: true,
+				Optional: true,
+			},
 		},
 	}
 }
 
+// ModifyPlan plans a null policies list when the policies are set through assignments, the
+// list kept from the prior state would not match the result of the apply.
+func (r *policyAssignmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var assignments types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("assignments"), &assignments)...)
+	if resp.Diagnostics.HasError() || assignments.IsNull() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("policies"), types.ListNull(types.StringType))...)
+}
+
 func (r *policyAssignmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -118,8 +171,18 @@ func (r *policyAssignmentResource) Create(ctx context.Context, req resource.Crea
 
 	state := data.State.ValueString()
 	tflog.Debug(ctx, "Creating policy assignment")
-	// default action is active
-	if state == "" || state == "enabled" {
+	if !data.Assignments.IsNull() {
+		// every policy has its own state
+		assignments := map[string]string{}
+		resp.Diagnostics.Append(data.Assignments.ElementsAs(ctx, &assignments, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		err = r.client.ApplyPolicyAssignments(ctx, space.MRN(), assignments)
+		policyMrns = slices.Sorted(maps.Keys(assignments))
+		data.PolicyMrns = types.ListNull(types.StringType)
+	} else if state == "" || state == "enabled" {
+		// default action is active
 		action := mondoov1.PolicyActionActive
 		err = r.client.AssignPolicy(ctx, space.MRN(), action, policyMrns)
 	} else if state == "preview" {
@@ -135,6 +198,10 @@ func (r *policyAssignmentResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	if err == nil && data.Authoritative.ValueBool() {
+		err = r.client.UnassignUnlistedPolicies(ctx, space.MRN(), "POLICY", policyMrns)
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating policy assignment",
@@ -157,17 +224,80 @@ func (r *policyAssignmentResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	// Read API call logic
+	// the assignments are gone with the space
+	space, err := r.client.ComputeSpace(data.SpaceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
+		return
+	}
+	if _, ok := r.client.ReadSpace(ctx, space.MRN(), resp); !ok {
+		return
+	}
+
+	// reconcile with what is actually assigned, policies might have been
+	// unassigned or switched to preview from the Mondoo Console
+	assigned, err := r.client.assignedPolicyStates(ctx, space.MRN(), "POLICY")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read policy assignments. Got error: %s", err),
+		)
+		return
+	}
+	if !data.Assignments.IsNull() {
+		assignments := map[string]string{}
+		resp.Diagnostics.Append(data.Assignments.ElementsAs(ctx, &assignments, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		listed := slices.Sorted(maps.Keys(assignments))
+		for _, mrn := range listed {
+			assignments[mrn] = policyAssignmentState(assigned, mrn)
+		}
+		if data.Authoritative.ValueBool() {
+			// policies assigned by other means show up in the plan as removals
+			for _, mrn := range unlistedPolicies(assigned, listed) {
+				assignments[mrn] = assigned[mrn]
+			}
+		}
+		var diags diag.Diagnostics
+		data.Assignments, diags = types.MapValueFrom(ctx, types.StringType, assignments)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	policyMrns := []string{}
+	resp.Diagnostics.Append(data.PolicyMrns.ElementsAs(ctx, &policyMrns, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	reconciled := policyMrns
+	if len(policyMrns) > 0 {
+		var state string
+		reconciled, state = reconcilePolicyAssignment(policyMrns, data.State.ValueString(), assigned)
+		data.State = types.StringValue(state)
+	}
+	if data.Authoritative.ValueBool() {
+		// policies assigned by other means show up in the plan as removals
+		reconciled = append(reconciled, unlistedPolicies(assigned, policyMrns)...)
+	}
+	if len(reconciled) > 0 {
+		var diags diag.Diagnostics
+		data.PolicyMrns, diags = types.ListValueFrom(ctx, types.StringType, reconciled)
+		resp.Diagnostics.Append(diags...)
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *policyAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data policyAssigmentsResourceModel
+	var data, prior policyAssigmentsResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -185,10 +315,27 @@ func (r *policyAssignmentResource) Update(ctx context.Context, req resource.Upda
 	policyMrns := []string{}
 	data.PolicyMrns.ElementsAs(ctx, &policyMrns, false)
 
+	// the prior policies and their state, whether they were listed in policies or assignments
+	priorAssignments, diags := policyAssignments(ctx, prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	state := data.State.ValueString()
 	tflog.Debug(ctx, "Updating policy assignment")
-	// default action is active
-	if state == "" || state == "enabled" {
+	if !data.Assignments.IsNull() {
+		// only touch the policies whose state changed
+		assignments := map[string]string{}
+		resp.Diagnostics.Append(data.Assignments.ElementsAs(ctx, &assignments, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		err = r.client.ApplyPolicyAssignments(ctx, space.MRN(), changedPolicyAssignments(priorAssignments, assignments))
+		policyMrns = slices.Sorted(maps.Keys(assignments))
+		data.PolicyMrns = types.ListNull(types.StringType)
+	} else if state == "" || state == "enabled" {
+		// default action is active
 		action := mondoov1.PolicyActionActive
 		err = r.client.AssignPolicy(ctx, space.MRN(), action, policyMrns)
 	} else if state == "preview" {
@@ -204,6 +351,17 @@ func (r *policyAssignmentResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	if err == nil && data.Assignments.IsNull() && !prior.Assignments.IsNull() {
+		// switching from assignments to policies, the policies no longer listed get unassigned
+		if removed := unlistedPolicies(priorAssignments, policyMrns); len(removed) > 0 {
+			err = r.client.UnassignPolicy(ctx, space.MRN(), removed)
+		}
+	}
+
+	if err == nil && data.Authoritative.ValueBool() {
+		err = r.client.UnassignUnlistedPolicies(ctx, space.MRN(), "POLICY", policyMrns)
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating policy assignment",
@@ -237,6 +395,14 @@ func (r *policyAssignmentResource) Delete(ctx context.Context, req resource.Dele
 	// Do GraphQL request to API to create the resource
 	policyMrns := []string{}
 	data.PolicyMrns.ElementsAs(ctx, &policyMrns, false)
+	if !data.Assignments.IsNull() {
+		assignments := map[string]string{}
+		resp.Diagnostics.Append(data.Assignments.ElementsAs(ctx, &assignments, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		policyMrns = slices.Sorted(maps.Keys(assignments))
+	}
 
 	tflog.Debug(ctx, "Deleting policy assignment")
 	// no matter the state, we unassign the policies
@@ -249,3 +415,214 @@ func (r *policyAssignmentResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 }
+
+func (r *policyAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	space, err := ParseSpace(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected the id or the MRN of the space. Got error: %s", err),
+		)
+		return
+	}
+	assigned, err := r.client.assignedPolicyStates(ctx, space.MRN(), "POLICY")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read policy assignments. Got error: %s", err),
+		)
+		return
+	}
+
+	data, diags := importedPolicyAssignment(ctx, space.ID(), assigned)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// importedPolicyAssignment builds the assignment of all the policies assigned to the space. When
+// they all share the same state, the assignment lists the policies with that state, otherwise it
+// maps every policy to its own state.
+func importedPolicyAssignment(ctx context.Context, spaceID string, assigned map[string]string) (policyAssigmentsResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	data := policyAssigmentsResourceModel{
+		SpaceID:       types.StringValue(spaceID),
+		PolicyMrns:    types.ListNull(types.StringType),
+		State:         types.StringValue("enabled"),
+		Assignments:   types.MapNull(types.StringType),
+		Authoritative: types.BoolValue(false),
+	}
+
+	states := slices.Compact(slices.Sorted(maps.Values(assigned)))
+	if len(states) > 1 {
+		data.Assignments, diags = types.MapValueFrom(ctx, types.StringType, assigned)
+		return data, diags
+	}
+	if len(states) == 1 {
+		data.State = types.StringValue(states[0])
+	}
+	data.PolicyMrns, diags = types.ListValueFrom(ctx, types.StringType, slices.Sorted(maps.Keys(assigned)))
+	return data, diags
+}
+
+// assignedPolicyStates returns the assignment state (enabled or preview) of every policy of the
+// catalog type assigned to the space, policies that are not listed are disabled.
+func (c *ExtendedGqlClient) assignedPolicyStates(ctx context.Context, spaceMrn string, catalogType string) (map[string]string, error) {
+	policies, err := c.GetPolicies(ctx, spaceMrn, catalogType, true)
+	if err != nil {
+		return nil, err
+	}
+
+	states := map[string]string{}
+	for _, policy := range *policies {
+		if !policy.Assigned {
+			continue
+		}
+		state := "enabled"
+		if string(policy.Action) == string(mondoov1.PolicyActionIgnore) {
+			state = "preview"
+		}
+		states[string(policy.Mrn)] = state
+	}
+	return states, nil
+}
+
+// policyAssignmentState returns the actual state of the policy, given the assigned policies.
+func policyAssignmentState(assigned map[string]string, mrn string) string {
+	if s, ok := assigned[mrn]; ok {
+		return s
+	}
+	return "disabled"
+}
+
+// reconcilePolicyAssignment compares the policies of an assignment with the ones actually
+// assigned. When all policies share the same actual state, that state is reported, so that
+// switching them in the console shows up as a change of state. Otherwise, the policies that
+// are not in the expected state are left out so that Terraform plans to assign them again.
+func reconcilePolicyAssignment(policyMrns []string, state string, assigned map[string]string) ([]string, string) {
+	actualState := func(mrn string) string {
+		return policyAssignmentState(assigned, mrn)
+	}
+
+	states := map[string]bool{}
+	for _, mrn := range policyMrns {
+		states[actualState(mrn)] = true
+	}
+	if len(states) == 1 {
+		for s := range states {
+			return policyMrns, s
+		}
+	}
+
+	reconciled := []string{}
+	for _, mrn := range policyMrns {
+		if actualState(mrn) == state {
+			reconciled = append(reconciled, mrn)
+		}
+	}
+	return reconciled, state
+}
+
+// unlistedPolicies returns the assigned policies that are not listed, sorted by MRN.
+func unlistedPolicies(assigned map[string]string, listed []string) []string {
+	isListed := map[string]bool{}
+	for _, mrn := range listed {
+		isListed[mrn] = true
+	}
+	unlisted := []string{}
+	for mrn := range assigned {
+		if !isListed[mrn] {
+			unlisted = append(unlisted, mrn)
+		}
+	}
+	sort.Strings(unlisted)
+	return unlisted
+}
+
+// UnassignUnlistedPolicies unassigns every policy of the catalog type assigned to the space that
+// is not listed, this is how authoritative assignments remove policies assigned by other means.
+func (c *ExtendedGqlClient) UnassignUnlistedPolicies(ctx context.Context, spaceMrn string, catalogType string, listed []string) error {
+	assigned, err := c.assignedPolicyStates(ctx, spaceMrn, catalogType)
+	if err != nil {
+		return err
+	}
+	unlisted := unlistedPolicies(assigned, listed)
+	if len(unlisted) == 0 {
+		return nil
+	}
+	tflog.Debug(ctx, "Unassigning unlisted policies", map[string]interface{}{
+		"policy_mrns": unlisted,
+	})
+	return c.UnassignPolicy(ctx, spaceMrn, unlisted)
+}
+
+// policyAssignments returns the state of every policy of the assignment, from either the
+// assignments map or the policies list and its state.
+func policyAssignments(ctx context.Context, data policyAssigmentsResourceModel) (map[string]string, diag.Diagnostics) {
+	assignments := map[string]string{}
+	if !data.Assignments.IsNull() {
+		diags := data.Assignments.ElementsAs(ctx, &assignments, false)
+		return assignments, diags
+	}
+	policyMrns := []string{}
+	diags := data.PolicyMrns.ElementsAs(ctx, &policyMrns, false)
+	state := data.State.ValueString()
+	if state == "" {
+		state = "enabled"
+	}
+	for _, mrn := range policyMrns {
+		assignments[mrn] = state
+	}
+	return assignments, diags
+}
+
+// changedPolicyAssignments returns the policies whose state changed between the prior and the
+// planned assignments, policies that are no longer listed get disabled.
+func changedPolicyAssignments(prior, planned map[string]string) map[string]string {
+	changed := map[string]string{}
+	for mrn, state := range planned {
+		if prior[mrn] != state {
+			changed[mrn] = state
+		}
+	}
+	for mrn, state := range prior {
+		if _, ok := planned[mrn]; !ok && state != "disabled" {
+			changed[mrn] = "disabled"
+		}
+	}
+	return changed
+}
+
+// ApplyPolicyAssignments assigns or unassigns every policy according to its state, policies
+// sharing the same state are sent together.
+func (c *ExtendedGqlClient) ApplyPolicyAssignments(ctx context.Context, spaceMrn string, assignments map[string]string) error {
+	byState := map[string][]string{}
+	for mrn, state := range assignments {
+		byState[state] = append(byState[state], mrn)
+	}
+
+	for _, state := range []string{"enabled", "preview", "disabled"} {
+		policyMrns := byState[state]
+		if len(policyMrns) == 0 {
+			continue
+		}
+		sort.Strings(policyMrns)
+
+		var err error
+		switch state {
+		case "enabled":
+			err = c.AssignPolicy(ctx, spaceMrn, mondoov1.PolicyActionActive, policyMrns)
+		case "preview":
+			err = c.AssignPolicy(ctx, spaceMrn, mondoov1.PolicyActionIgnore, policyMrns)
+		case "disabled":
+			err = c.UnassignPolicy(ctx, spaceMrn, policyMrns)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,10 +4,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mondoov1 "go.mondoo.com/mondoo-go"
 )
 
 func TestAccPolicyAssignmentResource(t *testing.T) {
@@ -15,6 +23,7 @@ func TestAccPolicyAssignmentResource(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	var spaceID string
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -24,15 +33,44 @@ func TestAccPolicyAssignmentResource(t *testing.T) {
 				Config: testAccPolicyAssignmentResourceConfig(orgID, "enabled"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("mondoo_policy_assignment.space", "state", "enabled"),
+					func(s *terraform.State) error {
+						spaceID = s.RootModule().Resources["mondoo_space.test"].Primary.ID
+						return nil
+					},
 				),
 			},
 			// ImportState testing
-			// NOTE: not implemented by resource
-			//{
-			//	ResourceName:      "mondoo_registration_token.test",
-			//	ImportState:       false,
-			//	ImportStateVerify: false,
-			//},
+			{
+				ResourceName: "mondoo_policy_assignment.space",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return spaceID, nil
+				},
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "space_id",
+			},
+			// the policy is switched to preview from the Mondoo Console, Terraform enables it again
+			{
+				PreConfig: func() {
+					client, err := mondooClient()
+					if err != nil {
+						t.Fatal(err)
+					}
+					extendedC := ExtendedGqlClient{Client: client, retry: defaultRetryConfig}
+					err = extendedC.AssignPolicy(context.Background(), SpaceFrom(spaceID).MRN(), mondoov1.PolicyActionIgnore,
+						[]string{"//policy.api.mondoo.app/policies/mondoo-aws-security"})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccPolicyAssignmentResourceConfig(orgID, "enabled"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mondoo_policy_assignment.space", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mondoo_policy_assignment.space", "state", "enabled"),
+			},
 			// Update and Read testing
 			{
 				Config: testAccPolicyAssignmentResourceConfig(orgID, "disabled"),
@@ -68,3 +106,241 @@ resource "mondoo_policy_assignment" "space" {
 }
 `, resourceOrgID, state)
 }
+
+func TestReconcilePolicyAssignment(t *testing.T) {
+	aws := "//policy.api.mondoo.app/policies/mondoo-aws-security"
+	linux := "//policy.api.mondoo.app/policies/mondoo-linux-security"
+
+	tests := []struct {
+		name       string
+		policyMrns []string
+		state      string
+		assigned   map[string]string
+		wantMrns   []string
+		wantState  string
+	}{
+		{"In sync", []string{aws, linux}, "enabled", map[string]string{aws: "enabled", linux: "enabled"}, []string{aws, linux}, "enabled"},
+		{"All switched to preview", []string{aws, linux}, "enabled", map[string]string{aws: "preview", linux: "preview"}, []string{aws, linux}, "preview"},
+		{"All unassigned", []string{aws}, "preview", map[string]string{}, []string{aws}, "disabled"},
+		{"Disabled in sync", []string{aws}, "disabled", map[string]string{linux: "enabled"}, []string{aws}, "disabled"},
+		{"One switched to preview", []string{aws, linux}, "enabled", map[string]string{aws: "enabled", linux: "preview"}, []string{aws}, "enabled"},
+		{"One unassigned", []string{aws, linux}, "preview", map[string]string{linux: "preview"}, []string{linux}, "preview"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policyMrns, state := reconcilePolicyAssignment(tt.policyMrns, tt.state, tt.assigned)
+			assert.Equal(t, tt.wantMrns, policyMrns)
+			assert.Equal(t, tt.wantState, state)
+		})
+	}
+}
+
+func TestUnlistedPolicies(t *testing.T) {
+	assigned := map[string]string{
+		"//policy.api.mondoo.app/policies/mondoo-linux-security": "enabled",
+		"//policy.api.mondoo.app/policies/mondoo-aws-security":   "preview",
+		"//policy.api.mondoo.app/policies/mondoo-gcp-security":   "enabled",
+	}
+	assert.Equal(t, []string{
+		"//policy.api.mondoo.app/policies/mondoo-gcp-security",
+		"//policy.api.mondoo.app/policies/mondoo-linux-security",
+	}, unlistedPolicies(assigned, []string{"//policy.api.mondoo.app/policies/mondoo-aws-security"}))
+	assert.Empty(t, unlistedPolicies(map[string]string{}, []string{"//policy.api.mondoo.app/policies/mondoo-aws-security"}))
+}
+
+func TestAccPolicyAssignmentResourceAuthoritative(t *testing.T) {
+	orgID, err := getOrgId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	linux := "//policy.api.mondoo.app/policies/mondoo-linux-security"
+
+	var spaceID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyAssignmentResourceAuthoritativeConfig(orgID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mondoo_policy_assignment.space", "authoritative", "true"),
+					resource.TestCheckResourceAttr("mondoo_policy_assignment.space", "policies.#", "1"),
+					func(s *terraform.State) error {
+						spaceID = s.RootModule().Resources["mondoo_space.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			// a policy is assigned from the Mondoo Console, Terraform unassigns it
+			{
+				PreConfig: func() {
+					client, err := mondooClient()
+					if err != nil {
+						t.Fatal(err)
+					}
+					extendedC := ExtendedGqlClient{Client: client, retry: defaultRetryConfig}
+					err = extendedC.AssignPolicy(context.Background(), SpaceFrom(spaceID).MRN(), mondoov1.PolicyActionActive, []string{linux})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccPolicyAssignmentResourceAuthoritativeConfig(orgID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mondoo_policy_assignment.space", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: func(s *terraform.State) error {
+					client, err := mondooClient()
+					if err != nil {
+						return err
+					}
+					extendedC := ExtendedGqlClient{Client: client, retry: defaultRetryConfig}
+					assigned, err := extendedC.assignedPolicyStates(context.Background(), SpaceFrom(spaceID).MRN(), "POLICY")
+					if err != nil {
+						return err
+					}
+					if _, ok := assigned[linux]; ok {
+						return fmt.Errorf("policy %s is still assigned", linux)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccPolicyAssignmentResourceAuthoritativeConfig(resourceOrgID string) string {
+	return fmt.Sprintf(`
+resource "mondoo_space" "test" {
+  org_id = %[1]q
+  name   = "authoritative-policy-assignment-test"
+}
+
+resource "mondoo_policy_assignment" "space" {
+  space_id      = mondoo_space.test.id
+  authoritative = true
+
+  policies = [
+    "//policy.api.mondoo.app/policies/mondoo-aws-security",
+  ]
+}
+`, resourceOrgID)
+}
+
+func TestChangedPolicyAssignments(t *testing.T) {
+	aws := "//policy.api.mondoo.app/policies/mondoo-aws-security"
+	linux := "//policy.api.mondoo.app/policies/mondoo-linux-security"
+	gcp := "//policy.api.mondoo.app/policies/mondoo-gcp-security"
+
+	prior := map[string]string{aws: "enabled", linux: "preview", gcp: "enabled"}
+	planned := map[string]string{aws: "enabled", linux: "enabled"}
+	assert.Equal(t, map[string]string{linux: "enabled", gcp: "disabled"}, changedPolicyAssignments(prior, planned))
+	assert.Empty(t, changedPolicyAssignments(planned, planned))
+	assert.Equal(t, planned, changedPolicyAssignments(map[string]string{}, planned))
+}
+
+func TestPolicyAssignments(t *testing.T) {
+	ctx := context.Background()
+	aws := "//policy.api.mondoo.app/policies/mondoo-aws-security"
+	linux := "//policy.api.mondoo.app/policies/mondoo-linux-security"
+
+	listed := policyAssigmentsResourceModel{
+		PolicyMrns:  types.ListValueMust(types.StringType, []attr.Value{types.StringValue(aws), types.StringValue(linux)}),
+		State:       types.StringValue("preview"),
+		Assignments: types.MapNull(types.StringType),
+	}
+	assignments, diags := policyAssignments(ctx, listed)
+	require.False(t, diags.HasError())
+	assert.Equal(t, map[string]string{aws: "preview", linux: "preview"}, assignments)
+
+	// switching from policies to assignments disables the policies no longer listed
+	assert.Equal(t, map[string]string{aws: "enabled", linux: "disabled"},
+		changedPolicyAssignments(assignments, map[string]string{aws: "enabled"}))
+
+	mapped := policyAssigmentsResourceModel{
+		PolicyMrns:  types.ListNull(types.StringType),
+		State:       types.StringNull(),
+		Assignments: types.MapValueMust(types.StringType, map[string]attr.Value{aws: types.StringValue("disabled")}),
+	}
+	assignments, diags = policyAssignments(ctx, mapped)
+	require.False(t, diags.HasError())
+	assert.Equal(t, map[string]string{aws: "disabled"}, assignments)
+}
+
+func TestImportedPolicyAssignment(t *testing.T) {
+	ctx := context.Background()
+	aws := "//policy.api.mondoo.app/policies/mondoo-aws-security"
+	linux := "//policy.api.mondoo.app/policies/mondoo-linux-security"
+	values := func(list types.List) []string {
+		mrns := []string{}
+		list.ElementsAs(ctx, &mrns, false)
+		return mrns
+	}
+
+	data, diags := importedPolicyAssignment(ctx, "hungry-poet-123456", map[string]string{linux: "preview", aws: "preview"})
+	require.False(t, diags.HasError())
+	assert.Equal(t, "hungry-poet-123456", data.SpaceID.ValueString())
+	assert.Equal(t, []string{aws, linux}, values(data.PolicyMrns))
+	assert.Equal(t, "preview", data.State.ValueString())
+	assert.True(t, data.Assignments.IsNull())
+	assert.False(t, data.Authoritative.ValueBool())
+
+	data, diags = importedPolicyAssignment(ctx, "hungry-poet-123456", map[string]string{linux: "preview", aws: "enabled"})
+	require.False(t, diags.HasError())
+	assert.True(t, data.PolicyMrns.IsNull())
+	assignments := map[string]string{}
+	data.Assignments.ElementsAs(ctx, &assignments, false)
+	assert.Equal(t, map[string]string{linux: "preview", aws: "enabled"}, assignments)
+
+	data, diags = importedPolicyAssignment(ctx, "hungry-poet-123456", map[string]string{})
+	require.False(t, diags.HasError())
+	assert.Empty(t, values(data.PolicyMrns))
+	assert.Equal(t, "enabled", data.State.ValueString())
+}
+
+func TestAccPolicyAssignmentResourceAssignments(t *testing.T) {
+	orgID, err := getOrgId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyAssignmentResourceAssignmentsConfig(orgID, "preview"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mondoo_policy_assignment.space", "assignments.%", "2"),
+					resource.TestCheckResourceAttr("mondoo_policy_assignment.space", "assignments.//policy.api.mondoo.app/policies/mondoo-aws-security", "enabled"),
+					resource.TestCheckResourceAttr("mondoo_policy_assignment.space", "assignments.//policy.api.mondoo.app/policies/mondoo-linux-security", "preview"),
+				),
+			},
+			{
+				Config: testAccPolicyAssignmentResourceAssignmentsConfig(orgID, "disabled"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mondoo_policy_assignment.space", "assignments.//policy.api.mondoo.app/policies/mondoo-aws-security", "enabled"),
+					resource.TestCheckResourceAttr("mondoo_policy_assignment.space", "assignments.//policy.api.mondoo.app/policies/mondoo-linux-security", "disabled"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPolicyAssignmentResourceAssignmentsConfig(resourceOrgID string, linuxState string) string {
+	return fmt.Sprintf(`
+resource "mondoo_space" "test" {
+  org_id = %[1]q
+  name   = "policy-assignments-test"
+}
+
+resource "mondoo_policy_assignment" "space" {
+  space_id = mondoo_space.test.id
+
+  assignments = {
+    "//policy.api.mondoo.app/policies/mondoo-aws-security"   = "enabled"
+    "//policy.api.mondoo.app/policies/mondoo-linux-security" = %[2]q
+  }
+}
+`, resourceOrgID, linuxState)
+}
@@ -7,11 +7,18 @@ import (
 	"context"
 	"encoding/base64"
 	"errors"
+	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -25,6 +32,8 @@ import (
 
 // Ensure MondooProvider satisfies various provider interfaces.
 var _ provider.Provider = &MondooProvider{}
+var _ provider.ProviderWithFunctions = &MondooProvider{}
+var _ provider.ProviderWithEphemeralResources = &MondooProvider{}
 
 // MondooProvider defines the provider implementation.
 type MondooProvider struct {
@@ -40,6 +49,17 @@ type MondooProviderModel struct {
 	Space       types.String `tfsdk:"space"`
 	Region      types.String `tfsdk:"region"`
 	Endpoint    types.String `tfsdk:"endpoint"`
+
+	MaxRetries    types.Int64  `tfsdk:"max_retries"`
+	MaxRetryDelay types.String `tfsdk:"max_retry_delay"`
+
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+
+	Proxy          types.String `tfsdk:"proxy"`
+	CaFile         types.String `tfsdk:"ca_file"`
+	ClientCertFile types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile  types.String `tfsdk:"client_key_file"`
+	TlsServerName  types.String `tfsdk:"tls_server_name"`
 }
 
 func (p *MondooProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -69,6 +89,55 @@ func (p *MondooProvider) Schema(ctx context.Context, _ provider.SchemaRequest, r
 				MarkdownDescription: "The endpoint url of the server to manage resources",
 				Optional:            true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of times a failed request is retried. Queries are retried on rate limits, "+
+					"server unavailability and network errors, mutations only when the request was rejected before being processed. "+
+					"Set to `0` to disable retries. Defaults to `%d`.", defaultMaxRetries),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_retry_delay": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The maximum time to wait between retries, as a duration like `30s` or `2m`. "+
+					"Defaults to `%s`.", defaultMaxRetryDelay),
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of requests sent to the Mondoo API at the same time, across all resources and data sources. " +
+					"Useful to avoid overwhelming the API in large workspaces, other requests wait for their turn. " +
+					"Defaults to no limit besides the Terraform parallelism.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"proxy": schema.StringAttribute{
+				MarkdownDescription: "The URL of the HTTP(S) proxy used to reach the Mondoo API, e.g. `http://proxy.example.com:3128`. " +
+					"Can also be set with the `MONDOO_API_PROXY` environment variable. If not set, the standard " +
+					"`HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables are honored.",
+				Optional: true,
+			},
+			"ca_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded bundle of certificate authorities to trust in addition to the system ones, " +
+					"e.g. for a TLS-inspecting proxy. Can also be set with the `MONDOO_API_CA_FILE` environment variable.",
+				Optional: true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded client certificate for mTLS. Requires `client_key_file`. " +
+					"Can also be set with the `MONDOO_API_CLIENT_CERT_FILE` environment variable.",
+				Optional: true,
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the PEM encoded private key of the client certificate. Requires `client_cert_file`. " +
+					"Can also be set with the `MONDOO_API_CLIENT_KEY_FILE` environment variable.",
+				Optional: true,
+			},
+			"tls_server_name": schema.StringAttribute{
+				MarkdownDescription: "The server name used to verify the certificate of the Mondoo API, when it differs from the endpoint host. " +
+					"Can also be set with the `MONDOO_API_TLS_SERVER_NAME` environment variable.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -94,6 +163,14 @@ func (p *MondooProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	configPath := os.Getenv("MONDOO_CONFIG_PATH")
 	token := os.Getenv("MONDOO_API_TOKEN")
 
+	// keep track of who we are authenticated as, see the mondoo_provider_identity data source
+	identity := providerIdentity{}
+	loadServiceAccount := func(data []byte) {
+		if err := identity.loadServiceAccount(data); err != nil {
+			tflog.Debug(ctx, "Unable to load service account identity", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
 	if configBase64 != "" {
 		// extract base 64 encoded string
 		data, err := base64.StdEncoding.DecodeString(configBase64)
@@ -106,15 +183,24 @@ func (p *MondooProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		}
 		opts = append(opts, option.WithServiceAccount(data))
 		ctx = tflog.SetField(ctx, "env_config_base64", true)
+		identity.credentialSource = credentialSourceConfigBase64
+		loadServiceAccount(data)
 	} else if configPath != "" {
 		opts = append(opts, option.WithServiceAccountFile(configPath))
 		ctx = tflog.SetField(ctx, "env_config_path", true)
+		identity.credentialSource = credentialSourceConfigPath
+		if data, err := os.ReadFile(configPath); err == nil {
+			loadServiceAccount(data)
+		}
 	} else if token != "" {
 		opts = append(opts, option.WithAPIToken(token))
 		ctx = tflog.SetField(ctx, "env_api_token", true)
+		identity.credentialSource = credentialSourceAPIToken
 	} else if data.Credentials.ValueString() != "" {
 		opts = append(opts, option.WithServiceAccount([]byte(data.Credentials.ValueString())))
 		ctx = tflog.SetField(ctx, "field_credentials", true)
+		identity.credentialSource = credentialSourceCredentials
+		loadServiceAccount([]byte(data.Credentials.ValueString()))
 	} else {
 		// if no option was provided, try the default location of Mondoo CLI configuration file
 		defaultConfigPath, err := detectDefaultConfig()
@@ -128,6 +214,10 @@ func (p *MondooProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		}
 		opts = append(opts, option.WithServiceAccountFile(defaultConfigPath))
 		ctx = tflog.SetField(ctx, "default_cli_config_file", true)
+		identity.credentialSource = credentialSourceCLIConfig
+		if data, err := os.ReadFile(defaultConfigPath); err == nil {
+			loadServiceAccount(data)
+		}
 	}
 	tflog.Debug(ctx, "Detected authentication credentials")
 
@@ -143,6 +233,7 @@ func (p *MondooProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		}
 		opts = append(opts, option.WithEndpoint(url))
 		ctx = tflog.SetField(ctx, "env_api_endpoint", true)
+		identity.apiEndpoint = strings.TrimSuffix(apiEndpoint, "/query")
 	} else if data.Endpoint.ValueString() != "" {
 		url := data.Endpoint.ValueString()
 		if !strings.HasSuffix(url, "/query") {
@@ -150,6 +241,7 @@ func (p *MondooProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		}
 		opts = append(opts, option.WithEndpoint(url))
 		ctx = tflog.SetField(ctx, "field_endpoint", true)
+		identity.apiEndpoint = strings.TrimSuffix(data.Endpoint.ValueString(), "/query")
 	} else if data.Region.ValueString() != "" {
 		switch data.Region.ValueString() {
 		case "eu":
@@ -158,6 +250,11 @@ func (p *MondooProvider) Configure(ctx context.Context, req provider.ConfigureRe
 			opts = append(opts, option.UseUSRegion())
 		}
 		ctx = tflog.SetField(ctx, "field_region", true)
+		identity.apiEndpoint = "https://" + data.Region.ValueString() + ".api.mondoo.com"
+	}
+	if identity.apiEndpoint == "" {
+		// neither configured nor coming from the service account
+		identity.apiEndpoint = defaultAPIEndpoint
 	}
 
 	space := data.Space.ValueString()
@@ -165,6 +262,55 @@ func (p *MondooProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		ctx = tflog.SetField(ctx, "provider_space", space)
 	}
 
+	// configure how failed requests are retried
+	retry := defaultRetryConfig
+	if !data.MaxRetries.IsNull() {
+		retry.maxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	if data.MaxRetryDelay.ValueString() != "" {
+		maxDelay, err := time.ParseDuration(data.MaxRetryDelay.ValueString())
+		if err != nil || maxDelay <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retry_delay"),
+				"Invalid max_retry_delay value: "+data.MaxRetryDelay.ValueString(),
+				"The maximum retry delay must be a positive duration like `30s` or `2m`.",
+			)
+			return
+		}
+		retry.maxDelay = maxDelay
+	}
+
+	// bound the requests in flight, this is shared by every resource and data source
+	limiter := newRequestLimiter(int(data.MaxConcurrentRequests.ValueInt64()))
+
+	// configure how to connect to the Mondoo API, environment variables take precedence
+	// like they do for the credentials and the endpoint
+	connection := transportConfig{
+		proxy:          envOrValue("MONDOO_API_PROXY", data.Proxy),
+		caFile:         envOrValue("MONDOO_API_CA_FILE", data.CaFile),
+		clientCertFile: envOrValue("MONDOO_API_CLIENT_CERT_FILE", data.ClientCertFile),
+		clientKeyFile:  envOrValue("MONDOO_API_CLIENT_KEY_FILE", data.ClientKeyFile),
+		tlsServerName:  envOrValue("MONDOO_API_TLS_SERVER_NAME", data.TlsServerName),
+	}
+	// only replace the HTTP client of mondoo-go when we have to, it takes care of the credentials
+	if connection.configured() {
+		transport, err := connection.httpTransport()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid connection configuration",
+				err.Error(),
+			)
+			return
+		}
+		var next http.RoundTripper = transport
+		if identity.credentialSource == credentialSourceAPIToken {
+			next = &apiTokenTransport{token: token, next: transport}
+		}
+		opts = append(opts, option.WithHTTPClient(&http.Client{
+			Transport: &retryTransport{next: next},
+		}))
+	}
+
 	tflog.Debug(ctx, "Creating Mondoo client")
 	client, err := mondoov1.NewClient(opts...)
 	if err != nil {
@@ -177,9 +323,10 @@ func (p *MondooProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 	// The extended GraphQL client allows us to pass additional information to
 	// resources and data sources, things like the Mondoo space
-	extendedClient := &ExtendedGqlClient{client, SpaceFrom(space)}
+	extendedClient := &ExtendedGqlClient{client, SpaceFrom(space), retry, identity, newQueryCache(), limiter}
 	resp.DataSourceData = extendedClient
 	resp.ResourceData = extendedClient
+	resp.EphemeralResourceData = extendedClient
 }
 
 func (p *MondooProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -220,7 +367,33 @@ func (p *MondooProvider) DataSources(ctx context.Context) []func() datasource.Da
 		NewPoliciesDataSource,
 		NewAssetsDataSource,
 		NewFrameworksDataSource,
+		NewProviderIdentityDataSource,
+	}
+}
+
+func (p *MondooProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewRegistrationTokenEphemeralResource,
+		NewServiceAccountCredentialEphemeralResource,
+	}
+}
+
+func (p *MondooProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewSpaceMrnFunction,
+		NewSpaceIdFunction,
+		NewOrgMrnFunction,
+		NewParseMrnFunction,
+		NewIntegrationSpaceIdFunction,
+	}
+}
+
+// envOrValue returns the value of the environment variable if set, otherwise the configured value.
+func envOrValue(env string, value types.String) string {
+	if v := os.Getenv(env); v != "" {
+		return v
 	}
+	return value.ValueString()
 }
 
 func New(version string) func() provider.Provider {
@@ -0,0 +1,211 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v2"
+)
+
+// The sources the provider reads credentials from, see MondooProvider.Configure.
+const (
+	credentialSourceConfigBase64 = "MONDOO_CONFIG_BASE64"
+	credentialSourceConfigPath   = "MONDOO_CONFIG_PATH"
+	credentialSourceAPIToken     = "MONDOO_API_TOKEN"
+	credentialSourceCredentials  = "credentials"
+	credentialSourceCLIConfig    = "cli_config"
+)
+
+const defaultAPIEndpoint = "https://us.api.mondoo.com"
+
+// providerIdentity describes who the provider is authenticated as.
+type providerIdentity struct {
+	credentialSource string
+	// the fields below are only known when authenticating with a service account
+	mrn                  string
+	scopeMrn             string
+	apiEndpoint          string
+	certificateExpiresAt time.Time
+}
+
+// serviceAccountConfig is a service account, either in the JSON format downloaded from the
+// Mondoo Console or in the YAML format of the Mondoo CLI configuration.
+type serviceAccountConfig struct {
+	Mrn         string `yaml:"mrn"`
+	ScopeMrn    string `yaml:"scope_mrn"`
+	SpaceMrn    string `yaml:"space_mrn"`
+	ParentMrn   string `yaml:"parent_mrn"`
+	ApiEndpoint string `yaml:"api_endpoint"`
+	Certificate string `yaml:"certificate"`
+}
+
+// loadServiceAccount fills the identity with the details of a service account.
+func (i *providerIdentity) loadServiceAccount(data []byte) error {
+	// JSON is valid YAML, so this handles both formats
+	var config serviceAccountConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return err
+	}
+
+	i.mrn = config.Mrn
+	i.apiEndpoint = strings.TrimSuffix(config.ApiEndpoint, "/query")
+	for _, scopeMrn := range []string{config.ScopeMrn, config.SpaceMrn, config.ParentMrn} {
+		if scopeMrn != "" {
+			i.scopeMrn = scopeMrn
+			break
+		}
+	}
+	if i.scopeMrn == "" {
+		// service accounts live in the scope they belong to, e.g.
+		// => "//agents.api.mondoo.app/organizations/{ID}/serviceaccounts/{ID}"
+		if mrn, err := ParseMrn(config.Mrn); err == nil {
+			if spaceID := mrn.IDs["spaces"]; spaceID != "" {
+				i.scopeMrn = SpaceFrom(spaceID).MRN()
+			} else if orgID := mrn.IDs["organizations"]; orgID != "" {
+				i.scopeMrn = orgPrefix + orgID
+			}
+		}
+	}
+
+	if config.Certificate != "" {
+		block, _ := pem.Decode([]byte(config.Certificate))
+		if block == nil {
+			return errors.New("the service account certificate is not PEM encoded")
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return err
+		}
+		i.certificateExpiresAt = cert.NotAfter
+	}
+	return nil
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ProviderIdentityDataSource{}
+
+func NewProviderIdentityDataSource() datasource.DataSource {
+	return &ProviderIdentityDataSource{}
+}
+
+// ProviderIdentityDataSource defines the data source implementation.
+type ProviderIdentityDataSource struct {
+	client *ExtendedGqlClient
+}
+
+// ProviderIdentityDataSourceModel describes the data source data model.
+type ProviderIdentityDataSourceModel struct {
+	CredentialSource     types.String `tfsdk:"credential_source"`
+	Mrn                  types.String `tfsdk:"mrn"`
+	ScopeMrn             types.String `tfsdk:"scope_mrn"`
+	SpaceID              types.String `tfsdk:"space_id"`
+	ApiEndpoint          types.String `tfsdk:"api_endpoint"`
+	CertificateExpiresAt types.String `tfsdk:"certificate_expires_at"`
+}
+
+func (d *ProviderIdentityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provider_identity"
+}
+
+func (d *ProviderIdentityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Shows who the provider is authenticated as, useful to verify a pipeline runs with the intended service account.
+
+API tokens do not carry the identity of a service account, only ` + "`credential_source`, `space_id` and `api_endpoint`" + ` are set when
+authenticating with ` + "`MONDOO_API_TOKEN`" + `.`,
+
+		Attributes: map[string]schema.Attribute{
+			"credential_source": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Where the provider credentials come from, one of `%s`, `%s`, `%s`, `%s` or `%s`.",
+					credentialSourceConfigBase64, credentialSourceConfigPath, credentialSourceAPIToken,
+					credentialSourceCredentials, credentialSourceCLIConfig),
+				Computed: true,
+			},
+			"mrn": schema.StringAttribute{
+				MarkdownDescription: "The Mondoo Resource Name (MRN) of the service account the provider is authenticated as. Always null when authenticating with an API token (`MONDOO_API_TOKEN`).",
+				Computed:            true,
+			},
+			"scope_mrn": schema.StringAttribute{
+				MarkdownDescription: "The MRN of the organization or space the service account belongs to. Always null when authenticating with an API token (`MONDOO_API_TOKEN`).",
+				Computed:            true,
+			},
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "The default space configured in the provider, if any.",
+				Computed:            true,
+			},
+			"api_endpoint": schema.StringAttribute{
+				MarkdownDescription: "The Mondoo API endpoint the provider talks to.",
+				Computed:            true,
+			},
+			"certificate_expires_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time when the service account certificate expires, in RFC3339 format. Always null when authenticating with an API token (`MONDOO_API_TOKEN`).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ProviderIdentityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ExtendedGqlClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ExtendedGqlClient. Got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ProviderIdentityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ProviderIdentityDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	identity := d.client.identity
+	data.CredentialSource = types.StringValue(identity.credentialSource)
+	data.ApiEndpoint = types.StringValue(identity.apiEndpoint)
+	data.Mrn = types.StringNull()
+	if identity.mrn != "" {
+		data.Mrn = types.StringValue(identity.mrn)
+	}
+	data.ScopeMrn = types.StringNull()
+	if identity.scopeMrn != "" {
+		data.ScopeMrn = types.StringValue(identity.scopeMrn)
+	}
+	data.SpaceID = types.StringNull()
+	if d.client.Space().ID() != "" {
+		data.SpaceID = types.StringValue(d.client.Space().ID())
+	}
+	data.CertificateExpiresAt = types.StringNull()
+	if !identity.certificateExpiresAt.IsZero() {
+		data.CertificateExpiresAt = types.StringValue(identity.certificateExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccProviderIdentityDataSource(t *testing.T) {
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttrSet("data.mondoo_provider_identity.test", "credential_source"),
+		resource.TestCheckResourceAttrSet("data.mondoo_provider_identity.test", "api_endpoint"),
+	}
+	if fakeAPI != nil {
+		checks = append(checks,
+			resource.TestCheckResourceAttr("data.mondoo_provider_identity.test", "credential_source", credentialSourceAPIToken),
+			resource.TestCheckResourceAttr("data.mondoo_provider_identity.test", "api_endpoint", fakeAPI.URL),
+			resource.TestCheckNoResourceAttr("data.mondoo_provider_identity.test", "mrn"),
+		)
+	} else {
+		checks = append(checks,
+			resource.TestCheckResourceAttrSet("data.mondoo_provider_identity.test", "mrn"),
+			resource.TestCheckResourceAttrSet("data.mondoo_provider_identity.test", "scope_mrn"),
+			resource.TestCheckResourceAttrSet("data.mondoo_provider_identity.test", "certificate_expires_at"),
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "mondoo" {
+  space = "` + accSpace.ID() + `"
+}
+
+data "mondoo_provider_identity" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(append(checks,
+					resource.TestCheckResourceAttr("data.mondoo_provider_identity.test", "space_id", accSpace.ID()),
+				)...),
+			},
+		},
+	})
+}
+
+func TestProviderIdentityLoadServiceAccount(t *testing.T) {
+	certFile, _ := writeClientCertificate(t)
+	certPEM, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	block, _ := pem.Decode(certPEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	t.Run("JSON service account", func(t *testing.T) {
+		data, err := json.Marshal(serviceAccountCredential{
+			Mrn:         "//agents.api.mondoo.app/organizations/lunalectric/serviceaccounts/2Abd3VXhNqnQk7Uw2sq0z7BfDwh",
+			Certificate: string(certPEM),
+			ApiEndpoint: "https://eu.api.mondoo.com",
+			ScopeMrn:    "//captain.api.mondoo.app/organizations/lunalectric",
+		})
+		require.NoError(t, err)
+
+		identity := providerIdentity{}
+		require.NoError(t, identity.loadServiceAccount(data))
+		assert.Equal(t, "//agents.api.mondoo.app/organizations/lunalectric/serviceaccounts/2Abd3VXhNqnQk7Uw2sq0z7BfDwh", identity.mrn)
+		assert.Equal(t, "//captain.api.mondoo.app/organizations/lunalectric", identity.scopeMrn)
+		assert.Equal(t, "https://eu.api.mondoo.com", identity.apiEndpoint)
+		assert.Equal(t, cert.NotAfter, identity.certificateExpiresAt)
+	})
+
+	t.Run("CLI configuration without scope", func(t *testing.T) {
+		data := []byte(`
+mrn: //agents.api.mondoo.app/spaces/hungry-poet-123456/serviceaccounts/2Abd3VXhNqnQk7Uw2sq0z7BfDwh
+api_endpoint: https://us.api.mondoo.com
+`)
+
+		identity := providerIdentity{}
+		require.NoError(t, identity.loadServiceAccount(data))
+		assert.Equal(t, "//captain.api.mondoo.app/spaces/hungry-poet-123456", identity.scopeMrn)
+		assert.True(t, identity.certificateExpiresAt.IsZero())
+	})
+
+	t.Run("Invalid certificate", func(t *testing.T) {
+		identity := providerIdentity{}
+		assert.Error(t, identity.loadServiceAccount([]byte(`{"certificate": "not a certificate"}`)))
+	})
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
 	mondoov1 "go.mondoo.com/mondoo-go"
 	"go.mondoo.com/mondoo-go/option"
 )
@@ -21,7 +22,18 @@ import (
 // Global space for those resources that need an existing space.
 var accSpace Space
 
+// fakeAPI is the in-process Mondoo API the acceptance tests run against when no
+// service account is configured via MONDOO_CONFIG_BASE64 or MONDOO_CONFIG_PATH.
+var fakeAPI *fakeMondooServer
+
 func TestMain(m *testing.M) {
+	if os.Getenv("MONDOO_CONFIG_BASE64") == "" && os.Getenv("MONDOO_CONFIG_PATH") == "" {
+		fakeAPI = newFakeMondooServer(fakeOrgID)
+		// the provider picks up the endpoint and credentials from the environment
+		os.Setenv("MONDOO_API_ENDPOINT", fakeAPI.URL)
+		os.Setenv("MONDOO_API_TOKEN", fakeAPIToken)
+	}
+
 	if err := createSpace(); err != nil {
 		panic(err)
 	}
@@ -31,6 +43,9 @@ func TestMain(m *testing.M) {
 	if err := deleteSpace(); err != nil {
 		panic(err)
 	}
+	if fakeAPI != nil {
+		fakeAPI.Close()
+	}
 	os.Exit(code)
 }
 
@@ -50,6 +65,13 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"mondoo": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccProtoV6ProviderFactoriesWithEcho includes the echo provider alongside the Mondoo provider,
+// it allows testing ephemeral resources since their data is never stored in the plan nor state.
+var testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
+	"mondoo": providerserver.NewProtocol6WithError(New("test")()),
+	"echo":   echoprovider.NewProviderServer(),
+}
+
 func testAccPreCheck(t *testing.T) {
 	// nothing to do here for now
 }
@@ -64,7 +86,7 @@ func createSpace() error {
 	if err != nil {
 		return err
 	}
-	extendedC := ExtendedGqlClient{client, ""}
+	extendedC := ExtendedGqlClient{Client: client, retry: defaultRetryConfig}
 
 	payload, err := extendedC.CreateSpace(context.Background(), orgID, "", "acceptance-test")
 	if err != nil {
@@ -80,12 +102,19 @@ func deleteSpace() error {
 	if err != nil {
 		return err
 	}
-	extendedC := ExtendedGqlClient{client, ""}
+	extendedC := ExtendedGqlClient{Client: client, retry: defaultRetryConfig}
 
 	return extendedC.DeleteSpace(context.Background(), accSpace.ID())
 }
 
 func mondooClient() (*mondoov1.Client, error) {
+	if fakeAPI != nil {
+		return mondoov1.NewClient(
+			option.WithEndpoint(fakeAPI.URL+"/query"),
+			option.WithAPIToken(os.Getenv("MONDOO_API_TOKEN")),
+		)
+	}
+
 	if configBase64 := os.Getenv("MONDOO_CONFIG_BASE64"); configBase64 != "" {
 		// extract base 64 encoded string
 		data, err := base64.StdEncoding.DecodeString(configBase64)
@@ -109,6 +138,10 @@ func getOrgId() (string, error) {
 	// You can add code here to run prior to any test case execution, for example assertions
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
+	if fakeAPI != nil {
+		return fakeOrgID, nil
+	}
+
 	var testCredentials serviceAccountCredentials
 
 	path := os.Getenv("MONDOO_CONFIG_PATH")
@@ -6,9 +6,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -22,7 +26,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ resource.Resource = (*queryPackAssignmentResource)(nil)
+var (
+	_ resource.Resource                = (*queryPackAssignmentResource)(nil)
+	_ resource.ResourceWithImportState = (*queryPackAssignmentResource)(nil)
+)
 
 func NewQueryPackAssigmentResource() resource.Resource {
 	return &queryPackAssignmentResource{}
@@ -41,6 +48,9 @@ type queryPackAssigmentsResourceModel struct {
 
 	// state
 	State types.String `tfsdk:"state"`
+
+	// unassign the query packs that are not listed
+	Authoritative types.Bool `tfsdk:"authoritative"`
 }
 
 func (r *queryPackAssignmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -76,6 +86,13 @@ func (r *queryPackAssignmentResource) Schema(_ context.Context, req resource.Sch
 					stringvalidator.OneOf("enabled", "disabled"),
 				},
 			},
+			"authoritative": schema.BoolAttribute{
+				MarkdownDescription: "Unassign every other query pack assigned to the space, so that the space query packs are fully described by this resource. " +
+					"Use a single authoritative query pack assignment per space. Defaults to `false`.",
+				Default:  booldefault.StaticBool(false),
+				Computed: true,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -138,6 +155,10 @@ func (r *queryPackAssignmentResource) Create(ctx context.Context, req resource.C
 		return
 	}
 
+	if err == nil && data.Authoritative.ValueBool() {
+		err = r.client.UnassignUnlistedPolicies(ctx, space.MRN(), "QUERYPACK", queryPackMrns)
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating query pack assignment",
@@ -163,7 +184,37 @@ func (r *queryPackAssignmentResource) Read(ctx context.Context, req resource.Rea
 		return
 	}
 
-	// Read API call logic
+	// the assignments are gone with the space
+	space, err := r.client.ComputeSpace(data.SpaceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
+		return
+	}
+	if _, ok := r.client.ReadSpace(ctx, space.MRN(), resp); !ok {
+		return
+	}
+
+	if data.Authoritative.ValueBool() {
+		// query packs assigned by other means show up in the plan as removals
+		assigned, err := r.client.assignedPolicyStates(ctx, space.MRN(), "QUERYPACK")
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to read query pack assignments. Got error: %s", err),
+			)
+			return
+		}
+		queryPackMrns := []string{}
+		resp.Diagnostics.Append(data.QueryPackMrns.ElementsAs(ctx, &queryPackMrns, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if unlisted := unlistedPolicies(assigned, queryPackMrns); len(unlisted) > 0 {
+			var diags diag.Diagnostics
+			data.QueryPackMrns, diags = types.ListValueFrom(ctx, types.StringType, append(queryPackMrns, unlisted...))
+			resp.Diagnostics.Append(diags...)
+		}
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -207,6 +258,10 @@ func (r *queryPackAssignmentResource) Update(ctx context.Context, req resource.U
 		return
 	}
 
+	if err == nil && data.Authoritative.ValueBool() {
+		err = r.client.UnassignUnlistedPolicies(ctx, space.MRN(), "QUERYPACK", queryPackMrns)
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating query pack assignment",
@@ -254,3 +309,38 @@ func (r *queryPackAssignmentResource) Delete(ctx context.Context, req resource.D
 		return
 	}
 }
+
+func (r *queryPackAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	space, err := ParseSpace(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected the id or the MRN of the space. Got error: %s", err),
+		)
+		return
+	}
+	assigned, err := r.client.assignedPolicyStates(ctx, space.MRN(), "QUERYPACK")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read query pack assignments. Got error: %s", err),
+		)
+		return
+	}
+
+	// query packs are either assigned or not, all the assigned ones are enabled
+	queryPackMrns, diags := types.ListValueFrom(ctx, types.StringType, slices.Sorted(maps.Keys(assigned)))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := queryPackAssigmentsResourceModel{
+		SpaceID:       types.StringValue(space.ID()),
+		QueryPackMrns: queryPackMrns,
+		State:         types.StringValue("enabled"),
+		Authoritative: types.BoolValue(false),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccQueryPackAssignmentResource(t *testing.T) {
@@ -27,12 +28,15 @@ func TestAccQueryPackAssignmentResource(t *testing.T) {
 				),
 			},
 			// ImportState testing
-			// NOTE: not implemented by resource
-			//{
-			//	ResourceName:      "mondoo_registration_token.test",
-			//	ImportState:       false,
-			//	ImportStateVerify: false,
-			//},
+			{
+				ResourceName: "mondoo_querypack_assignment.space",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["mondoo_space.test"].Primary.ID, nil
+				},
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "space_id",
+			},
 			// Update and Read testing
 			{
 				Config: testAccQueryPackAssignmentResourceConfig(orgID, "disabled"),
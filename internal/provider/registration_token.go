@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -19,6 +21,8 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &RegistrationTokenResource{}
+var _ resource.ResourceWithImportState = &RegistrationTokenResource{}
+var _ resource.ResourceWithModifyPlan = &RegistrationTokenResource{}
 
 func NewRegistrationTokenResource() resource.Resource {
 	return &RegistrationTokenResource{}
@@ -40,6 +44,7 @@ type RegistrationTokenResourceModel struct {
 	Description  types.String `tfsdk:"description"`
 	NoExpiration types.Bool   `tfsdk:"no_expiration"`
 	ExpiresIn    types.String `tfsdk:"expires_in"`
+	RotateBefore types.String `tfsdk:"rotate_before"`
 
 	// output
 	ExpiresAt types.String `tfsdk:"expires_at"`
@@ -86,20 +91,39 @@ func (r *RegistrationTokenResource) Schema(ctx context.Context, req resource.Sch
 				MarkdownDescription: "The duration after which the token will expire. Format: 1h, 1d, 1w, 1m, 1y",
 				Optional:            true,
 			},
+			"rotate_before": schema.StringAttribute{
+				MarkdownDescription: "Replace the token when it expires within this duration, e.g. `168h`. Use `0s` to replace the token once it has expired.",
+				Optional:            true,
+			},
 			"revoked": schema.BoolAttribute{
-				MarkdownDescription: "If set to true, the token is revoked.",
+				MarkdownDescription: "If set to true, the token is revoked. A revoked token cannot be restored, it is replaced instead, this includes tokens revoked outside of Terraform when `revoked` is not set.",
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.StateValue.ValueBool() && !req.PlanValue.IsUnknown() && !req.PlanValue.ValueBool()
+						},
+						"A revoked token cannot be restored, it is replaced.",
+						"A revoked token cannot be restored, it is replaced.",
+					),
+				},
 			},
 			"expires_at": schema.StringAttribute{
 				MarkdownDescription: "The date and time when the token will expire.",
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"result": schema.StringAttribute{
 				Description: "The generated token.",
 				Computed:    true,
 				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -179,21 +203,7 @@ func (r *RegistrationTokenResource) Create(ctx context.Context, req resource.Cre
 		NoExpiration: noExpiration,
 	}
 
-	tflog.Trace(ctx, "RegistrationTokenInput", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", registrationTokenInput),
-	})
-
-	var generateRegistrationToken struct {
-		RegistrationToken struct {
-			Mrn         mondoov1.String
-			Description mondoov1.String
-			Token       mondoov1.String
-			Revoked     mondoov1.Boolean
-			ExpiresAt   mondoov1.String
-		} `graphql:"generateRegistrationToken(input: $input)"`
-	}
-
-	err = r.client.Mutate(ctx, &generateRegistrationToken, registrationTokenInput, nil)
+	registrationToken, err := r.client.GenerateRegistrationToken(ctx, registrationTokenInput)
 	if err != nil {
 		resp.Diagnostics.
 			AddError("Client Error",
@@ -204,10 +214,10 @@ func (r *RegistrationTokenResource) Create(ctx context.Context, req resource.Cre
 
 	// Save space mrn into the Terraform state.
 	data.Description = types.StringValue(description)
-	data.Mrn = types.StringValue(string(generateRegistrationToken.RegistrationToken.Mrn))
-	data.Result = types.StringValue(string(generateRegistrationToken.RegistrationToken.Token))
-	data.Revoked = types.BoolValue(bool(generateRegistrationToken.RegistrationToken.Revoked))
-	data.ExpiresAt = types.StringValue(string(generateRegistrationToken.RegistrationToken.ExpiresAt))
+	data.Mrn = types.StringValue(string(registrationToken.Mrn))
+	data.Result = types.StringValue(string(registrationToken.Token))
+	data.Revoked = types.BoolValue(bool(registrationToken.Revoked))
+	data.ExpiresAt = types.StringValue(string(registrationToken.ExpiresAt))
 
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "created a token resource")
@@ -226,22 +236,56 @@ func (r *RegistrationTokenResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	// nothing to do here, we already have the data in the state
+	token, err := r.client.GetRegistrationToken(ctx, data.Mrn.ValueString())
+	if err == nil && token.Mrn == "" {
+		err = newNotFoundError("registration token", data.Mrn.ValueString())
+	}
+	if removeIfNotFound(ctx, err, resp) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read registration token. Got error: %s", err),
+		)
+		return
+	}
+
+	// the token might have been revoked from the Mondoo Console
+	data.Description = RefreshStringValue(data.Description, string(token.Description))
+	data.Revoked = types.BoolValue(bool(token.Revoked))
+	data.ExpiresAt = RefreshStringValue(data.ExpiresAt, string(token.ExpiresAt))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *RegistrationTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data RegistrationTokenResourceModel
+	var data, prior RegistrationTokenResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if data.Revoked.IsUnknown() {
+		data.Revoked = prior.Revoked
+	}
+	if data.Revoked.ValueBool() && !prior.Revoked.ValueBool() {
+		tflog.Debug(ctx, "Revoking registration token", map[string]interface{}{"mrn": data.Mrn.ValueString()})
+		err := r.client.RevokeRegistrationToken(ctx, data.Mrn.ValueString())
+		if err != nil {
+			resp.Diagnostics.
+				AddError("Client Error",
+					fmt.Sprintf("Unable to revoke registration token. Got error: %s", err),
+				)
+			return
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -256,44 +300,131 @@ func (r *RegistrationTokenResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
+	if data.Revoked.ValueBool() {
+		// nothing left to do, revoked tokens cannot be used anymore
+		return
+	}
+
 	// Do GraphQL request to API to revoke the token.
-	var revokeMutation struct {
-		RevokeRegistrationTokenResponse struct {
-			RevokeRegistrationTokenSuccess struct {
-				Ok mondoov1.Boolean
-			} `graphql:"... on RevokeRegistrationTokenSuccess"`
-			RevokeRegistrationTokenFailure struct {
-				Message mondoov1.String
-				Code    mondoov1.String
-			} `graphql:"... on RevokeRegistrationTokenFailure"`
-		} `graphql:"revokeRegistrationToken(input: $input)"`
+	err := r.client.RevokeRegistrationToken(ctx, data.Mrn.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.
+			AddError("Client Error",
+				fmt.Sprintf("Unable to revoke registration token. Got error: %s", err),
+			)
+		return
 	}
+}
 
-	// Compute and validate the space
-	space, err := r.client.ComputeSpace(data.SpaceID)
+// ModifyPlan replaces the token when it was revoked outside of Terraform or when it expires
+// within the rotate_before duration.
+func (r *RegistrationTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to replace when the token gets created or destroyed
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state, config RegistrationTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a revoked token cannot be restored, unless the configuration revokes it too
+	if state.Revoked.ValueBool() && config.Revoked.IsNull() {
+		tflog.Debug(ctx, "Registration token was revoked, replacing it", map[string]interface{}{
+			"mrn": state.Mrn.ValueString(),
+		})
+		planRegistrationTokenReplacement(config, &plan)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("revoked"))
+		return
+	}
+
+	if plan.RotateBefore.IsNull() || plan.RotateBefore.IsUnknown() {
+		return
+	}
+
+	rotateBefore, err := time.ParseDuration(plan.RotateBefore.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rotate_before"),
+			"Invalid rotate_before value: "+plan.RotateBefore.ValueString(),
+			fmt.Sprintf("Expected a duration like 168h or 30m. Got error: %s", err),
+		)
 		return
 	}
-	ctx = tflog.SetField(ctx, "space_mrn", space.MRN())
 
-	revokeInput := mondoov1.RevokeRegistrationTokenInput{
-		Mrn: mondoov1.String(space.MRN()),
+	if !registrationTokenNeedsRotation(state.ExpiresAt.ValueString(), rotateBefore, time.Now()) {
+		return
 	}
-	tflog.Trace(ctx, "RevokeRegistrationTokenInput", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", revokeInput),
+	tflog.Debug(ctx, "Registration token expires soon, replacing it", map[string]interface{}{
+		"expires_at": state.ExpiresAt.ValueString(),
 	})
-	err = r.client.Mutate(ctx, &revokeMutation, revokeInput, nil)
+	planRegistrationTokenReplacement(config, &plan)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expires_at"))
+}
+
+// planRegistrationTokenReplacement marks the values computed for the new token as unknown,
+// configured values are kept as they are.
+func planRegistrationTokenReplacement(config RegistrationTokenResourceModel, plan *RegistrationTokenResourceModel) {
+	plan.Mrn = types.StringUnknown()
+	plan.Result = types.StringUnknown()
+	if config.ExpiresAt.IsNull() {
+		plan.ExpiresAt = types.StringUnknown()
+	}
+	if config.Revoked.IsNull() {
+		plan.Revoked = types.BoolUnknown()
+	}
+}
+
+// registrationTokenNeedsRotation checks if the token expires within the rotateBefore duration,
+// tokens without expiration never need to be rotated.
+func registrationTokenNeedsRotation(expiresAt string, rotateBefore time.Duration, now time.Time) bool {
+	expires, err := time.Parse(time.RFC3339, expiresAt)
 	if err != nil {
-		resp.Diagnostics.
-			AddError("Client Error",
-				fmt.Sprintf("Unable to update token. Got error: %s", err),
-			)
-		return
+		return false
 	}
+	return !now.Add(rotateBefore).Before(expires)
 }
 
-// We do not support the import of this resource yet.
-//func (r *RegistrationTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-//	resource.ImportStatePassthroughID(ctx, path.Root("mrn"), req, resp)
-//}
+func (r *RegistrationTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	mrn, err := ParseMrn(req.ID)
+	if err == nil && mrn.IDs["spaces"] == "" {
+		err = fmt.Errorf("invalid MRN %q, expected the MRN of a space registration token", req.ID)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	token, err := r.client.GetRegistrationToken(ctx, req.ID)
+	if err == nil && token.Mrn == "" {
+		err = newNotFoundError("registration token", req.ID)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read registration token. Got error: %s", err),
+		)
+		return
+	}
+
+	// the token itself is only returned when it is generated
+	data := RegistrationTokenResourceModel{
+		Mrn:          types.StringValue(string(token.Mrn)),
+		SpaceID:      types.StringValue(mrn.IDs["spaces"]),
+		Description:  RefreshStringValue(types.StringNull(), string(token.Description)),
+		NoExpiration: types.BoolNull(),
+		ExpiresIn:    types.StringNull(),
+		RotateBefore: types.StringNull(),
+		ExpiresAt:    types.StringValue(string(token.ExpiresAt)),
+		Revoked:      types.BoolValue(bool(token.Revoked)),
+		Result:       types.StringNull(),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
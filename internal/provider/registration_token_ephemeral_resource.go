@@ -0,0 +1,206 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mondoov1 "go.mondoo.com/mondoo-go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &RegistrationTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &RegistrationTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &RegistrationTokenEphemeralResource{}
+
+// registrationTokenPrivateKey is the private data key holding the MRN of the token to revoke on close.
+const registrationTokenPrivateKey = "registration_token_mrn"
+
+func NewRegistrationTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &RegistrationTokenEphemeralResource{}
+}
+
+// RegistrationTokenEphemeralResource defines the ephemeral resource implementation.
+type RegistrationTokenEphemeralResource struct {
+	client *ExtendedGqlClient
+}
+
+// RegistrationTokenEphemeralResourceModel describes the ephemeral resource data model.
+type RegistrationTokenEphemeralResourceModel struct {
+	// scope
+	SpaceID types.String `tfsdk:"space_id"`
+
+	// registration token details
+	Description   types.String `tfsdk:"description"`
+	ExpiresIn     types.String `tfsdk:"expires_in"`
+	RevokeOnClose types.Bool   `tfsdk:"revoke_on_close"`
+
+	// output
+	Mrn       types.String `tfsdk:"mrn"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+	Result    types.String `tfsdk:"result"`
+}
+
+func (r *RegistrationTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_registration_token"
+}
+
+func (r *RegistrationTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Generates a registration token that is never persisted in the Terraform plan nor state.
+
+A new token is generated every time Terraform needs it, the token always expires, use ` + "`expires_in`" + ` to keep it short-lived.`,
+
+		Attributes: map[string]schema.Attribute{
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the Mondoo space in which to create the token. If there is no space ID, the provider space is used.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the token.",
+				Optional:            true,
+			},
+			"expires_in": schema.StringAttribute{
+				MarkdownDescription: "The duration after which the token will expire, e.g. `30m` or `2h`. If not set, the default expiration of the Mondoo Platform applies.",
+				Optional:            true,
+			},
+			"revoke_on_close": schema.BoolAttribute{
+				MarkdownDescription: "If set to true, the token is revoked once Terraform no longer needs it. Only use it when the token is consumed during the Terraform run.",
+				Optional:            true,
+			},
+			"mrn": schema.StringAttribute{
+				MarkdownDescription: "The Mondoo Resource Name (MRN) of the generated token.",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time when the token will expire.",
+				Computed:            true,
+			},
+			"result": schema.StringAttribute{
+				MarkdownDescription: "The generated token.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *RegistrationTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ExtendedGqlClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ExtendedGqlClient. Got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RegistrationTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data RegistrationTokenEphemeralResourceModel
+
+	// Read Terraform config data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Compute and validate the space
+	space, err := r.client.ComputeSpace(data.SpaceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
+		return
+	}
+	ctx = tflog.SetField(ctx, "space_mrn", space.MRN())
+
+	var expiresIn *mondoov1.Int
+	if data.ExpiresIn.ValueString() != "" {
+		duration, err := time.ParseDuration(data.ExpiresIn.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expires_in"),
+				"Invalid expires_in value",
+				fmt.Sprintf("Unable to parse duration %q. Got error: %s", data.ExpiresIn.ValueString(), err),
+			)
+			return
+		}
+		expiresIn = mondoov1.NewIntPtr(mondoov1.Int(duration.Seconds()))
+	}
+
+	registrationToken, err := r.client.GenerateRegistrationToken(ctx, mondoov1.RegistrationTokenInput{
+		Description: mondoov1.NewStringPtr(mondoov1.String(data.Description.ValueString())),
+		ScopeMrn:    mondoov1.NewStringPtr(mondoov1.String(space.MRN())),
+		ExpiresIn:   expiresIn,
+	})
+	if err != nil {
+		resp.Diagnostics.
+			AddError("Client Error",
+				fmt.Sprintf("Unable to create registration token. Got error: %s", err),
+			)
+		return
+	}
+
+	data.SpaceID = types.StringValue(space.ID())
+	data.Mrn = types.StringValue(string(registrationToken.Mrn))
+	data.ExpiresAt = types.StringValue(string(registrationToken.ExpiresAt))
+	data.Result = types.StringValue(string(registrationToken.Token))
+
+	if data.RevokeOnClose.ValueBool() {
+		// remember which token to revoke once Terraform is done with it
+		mrn, err := json.Marshal(data.Mrn.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to store registration token MRN. Got error: %s", err))
+			return
+		}
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, registrationTokenPrivateKey, mrn)...)
+	}
+
+	tflog.Trace(ctx, "opened a registration token ephemeral resource")
+
+	// Save data into the ephemeral result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *RegistrationTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	value, diags := req.Private.GetKey(ctx, registrationTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || value == nil {
+		// the token should not be revoked
+		return
+	}
+
+	var mrn string
+	if err := json.Unmarshal(value, &mrn); err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to read registration token MRN. Got error: %s", err))
+		return
+	}
+
+	err := r.client.RevokeRegistrationToken(ctx, mrn)
+	if err != nil {
+		resp.Diagnostics.
+			AddError("Client Error",
+				fmt.Sprintf("Unable to revoke registration token. Got error: %s", err),
+			)
+		return
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccRegistrationTokenEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRegistrationTokenEphemeralResourceConfig(accSpace.ID(), false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.space_id", accSpace.ID()),
+					resource.TestCheckResourceAttr("echo.test", "data.description", "ephemeral"),
+					resource.TestMatchResourceAttr("echo.test", "data.mrn", regexp.MustCompile(`/registration_tokens/`)),
+					resource.TestCheckResourceAttrSet("echo.test", "data.expires_at"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.result"),
+					testAccCheckRegistrationTokenRevoked("echo.test", false),
+				),
+			},
+			{
+				Config: testAccRegistrationTokenEphemeralResourceConfig(accSpace.ID(), true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("echo.test", "data.result"),
+					testAccCheckRegistrationTokenRevoked("echo.test", true),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckRegistrationTokenRevoked checks if the token was revoked when the ephemeral
+// resource was closed, only the fake Mondoo API lets us inspect revoked tokens.
+func testAccCheckRegistrationTokenRevoked(resourceName string, revoked bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if fakeAPI == nil {
+			return nil
+		}
+		mrn := s.RootModule().Resources[resourceName].Primary.Attributes["data.mrn"]
+
+		fakeAPI.mu.Lock()
+		defer fakeAPI.mu.Unlock()
+		token, ok := fakeAPI.registrationTokens[mrn]
+		if !ok {
+			return fmt.Errorf("registration token %s not found", mrn)
+		}
+		if token.boolean("revoked") != revoked {
+			return fmt.Errorf("expected registration token %s revoked to be %t", mrn, revoked)
+		}
+		return nil
+	}
+}
+
+func testAccRegistrationTokenEphemeralResourceConfig(spaceID string, revokeOnClose bool) string {
+	return fmt.Sprintf(`
+ephemeral "mondoo_registration_token" "test" {
+  space_id        = %[1]q
+  description     = "ephemeral"
+  expires_in      = "1h"
+  revoke_on_close = %[2]t
+}
+
+provider "echo" {
+  data = ephemeral.mondoo_registration_token.test
+}
+
+resource "echo" "test" {}
+`, spaceID, revokeOnClose)
+}
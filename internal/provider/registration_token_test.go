@@ -4,10 +4,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccRegistrationTokenResource(t *testing.T) {
@@ -15,6 +21,7 @@ func TestAccRegistrationTokenResource(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	var tokenMrn string
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -24,15 +31,24 @@ func TestAccRegistrationTokenResource(t *testing.T) {
 				Config: testAccRegistrationTokenResourceConfig(orgID, "one"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("mondoo_registration_token.test", "description", "one"),
+					func(s *terraform.State) error {
+						tokenMrn = s.RootModule().Resources["mondoo_registration_token.test"].Primary.Attributes["mrn"]
+						return nil
+					},
 				),
 			},
 			// ImportState testing
-			// NOTE: not implemented by resource
-			//{
-			//	ResourceName:      "mondoo_registration_token.test",
-			//	ImportState:       false,
-			//	ImportStateVerify: false,
-			//},
+			{
+				ResourceName: "mondoo_registration_token.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["mondoo_registration_token.test"].Primary.Attributes["mrn"], nil
+				},
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "mrn",
+				// the token itself is only returned when it is generated
+				ImportStateVerifyIgnore: []string{"result"},
+			},
 			// Update and Read testing
 			{
 				Config: testAccRegistrationTokenResourceConfig(orgID, "one"),
@@ -40,11 +56,150 @@ func TestAccRegistrationTokenResource(t *testing.T) {
 					resource.TestCheckResourceAttr("mondoo_registration_token.test", "description", "one"),
 				),
 			},
+			// the token is revoked from the Mondoo Console
+			{
+				PreConfig: func() {
+					client, err := mondooClient()
+					if err != nil {
+						t.Fatal(err)
+					}
+					extendedC := ExtendedGqlClient{Client: client, retry: defaultRetryConfig}
+					err = extendedC.RevokeRegistrationToken(context.Background(), tokenMrn)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				RefreshState: true,
+				Check:        resource.TestCheckResourceAttr("mondoo_registration_token.test", "revoked", "true"),
+			},
+			// the revoked token is replaced
+			{
+				Config: testAccRegistrationTokenResourceConfig(orgID, "one"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mondoo_registration_token.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mondoo_registration_token.test", "revoked", "false"),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
 
+func TestAccRegistrationTokenResourceRevoke(t *testing.T) {
+	orgID, err := getOrgId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRegistrationTokenResourceRevokedConfig(orgID, false),
+				Check:  resource.TestCheckResourceAttr("mondoo_registration_token.test", "revoked", "false"),
+			},
+			// revoking the token updates it in place
+			{
+				Config: testAccRegistrationTokenResourceRevokedConfig(orgID, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mondoo_registration_token.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mondoo_registration_token.test", "revoked", "true"),
+			},
+			// a revoked token cannot be restored, it is replaced
+			{
+				Config: testAccRegistrationTokenResourceRevokedConfig(orgID, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mondoo_registration_token.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mondoo_registration_token.test", "revoked", "false"),
+			},
+		},
+	})
+}
+
+func TestAccRegistrationTokenResourceRotateBefore(t *testing.T) {
+	orgID, err := getOrgId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRegistrationTokenResourceRotateConfig(orgID, "1h"),
+			},
+			// the token expires in 24h, it is replaced when it must be rotated 48h before
+			{
+				Config: testAccRegistrationTokenResourceRotateConfig(orgID, "48h"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mondoo_registration_token.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestRegistrationTokenNeedsRotation(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		expiresAt    string
+		rotateBefore time.Duration
+		rotate       bool
+	}{
+		{"Far from expiry", "2025-07-01T12:00:00Z", 7 * 24 * time.Hour, false},
+		{"Close to expiry", "2025-06-05T12:00:00Z", 7 * 24 * time.Hour, true},
+		{"Expired", "2025-05-31T12:00:00Z", 0, true},
+		{"Not expired", "2025-06-01T12:00:01Z", 0, false},
+		{"No expiration", "", 7 * 24 * time.Hour, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.rotate, registrationTokenNeedsRotation(tt.expiresAt, tt.rotateBefore, now))
+		})
+	}
+}
+
+func TestPlanRegistrationTokenReplacement(t *testing.T) {
+	state := RegistrationTokenResourceModel{
+		Mrn:       types.StringValue("//captain.api.mondoo.app/spaces/test-space/registration-tokens/token"),
+		ExpiresAt: types.StringValue("2025-06-05T12:00:00Z"),
+		Revoked:   types.BoolValue(false),
+		Result:    types.StringValue("token"),
+	}
+
+	plan := state
+	planRegistrationTokenReplacement(RegistrationTokenResourceModel{
+		ExpiresAt: types.StringNull(),
+		Revoked:   types.BoolNull(),
+	}, &plan)
+	assert.True(t, plan.Mrn.IsUnknown())
+	assert.True(t, plan.Result.IsUnknown())
+	assert.True(t, plan.ExpiresAt.IsUnknown())
+	assert.True(t, plan.Revoked.IsUnknown())
+
+	// configured values are known, marking them unknown would make the plan invalid
+	plan = state
+	planRegistrationTokenReplacement(RegistrationTokenResourceModel{
+		ExpiresAt: types.StringValue("2025-06-05T12:00:00Z"),
+		Revoked:   types.BoolValue(false),
+	}, &plan)
+	assert.True(t, plan.Mrn.IsUnknown())
+	assert.Equal(t, state.ExpiresAt, plan.ExpiresAt)
+	assert.Equal(t, state.Revoked, plan.Revoked)
+}
+
 func testAccRegistrationTokenResourceConfig(resourceOrgID, configurableAttribute string) string {
 	return fmt.Sprintf(`
 
@@ -59,3 +214,36 @@ resource "mondoo_registration_token" "test" {
 }
 `, resourceOrgID, configurableAttribute)
 }
+
+func testAccRegistrationTokenResourceRevokedConfig(resourceOrgID string, revoked bool) string {
+	return fmt.Sprintf(`
+
+resource "mondoo_space" "test" {
+  org_id = %[1]q
+  name = "registration-token-test"
+}
+
+resource "mondoo_registration_token" "test" {
+  space_id = mondoo_space.test.id
+  description = "revoked"
+  revoked = %[2]t
+}
+`, resourceOrgID, revoked)
+}
+
+func testAccRegistrationTokenResourceRotateConfig(resourceOrgID string, rotateBefore string) string {
+	return fmt.Sprintf(`
+
+resource "mondoo_space" "test" {
+  org_id = %[1]q
+  name = "registration-token-test"
+}
+
+resource "mondoo_registration_token" "test" {
+  space_id = mondoo_space.test.id
+  description = "rotated"
+  expires_in = "24h"
+  rotate_before = %[2]q
+}
+`, resourceOrgID, rotateBefore)
+}
@@ -0,0 +1,220 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mondoov1 "go.mondoo.com/mondoo-go"
+)
+
+const (
+	defaultMaxRetries    = 3
+	defaultMaxRetryDelay = 30 * time.Second
+	baseRetryDelay       = 500 * time.Millisecond
+)
+
+// retryConfig configures how failed GraphQL requests are retried.
+type retryConfig struct {
+	maxRetries int
+	maxDelay   time.Duration
+}
+
+var defaultRetryConfig = retryConfig{
+	maxRetries: defaultMaxRetries,
+	maxDelay:   defaultMaxRetryDelay,
+}
+
+// backoff returns how long to wait before the provided (zero based) retry attempt. The server
+// knows best, so we honor Retry-After, otherwise we use exponential backoff with jitter.
+func (r retryConfig) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, r.maxDelay)
+	}
+	delay := r.maxDelay
+	if attempt < 30 { // avoid overflows
+		delay = min(baseRetryDelay<<attempt, r.maxDelay)
+	}
+	// equal jitter, wait at least half of the delay
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// requestInfoKey is the context key used to pass a requestInfo to the HTTP transport.
+type requestInfoKey struct{}
+
+// requestInfo collects what the HTTP transport saw about a GraphQL request, the GraphQL
+// client doesn't expose status codes nor headers, we need them to decide if we can retry.
+type requestInfo struct {
+	statusCode   int
+	retryAfter   time.Duration
+	transportErr error
+	// notSent is true when the request never reached the server
+	notSent bool
+	// errorPaths are the paths of the GraphQL errors in the response
+	errorPaths []string
+}
+
+// retryable checks if a failed request can be retried. Queries are safe to retry on any
+// transient error, mutations are only retried if we know the server did not process them.
+func (i *requestInfo) retryable(idempotent bool) bool {
+	if i.statusCode == http.StatusTooManyRequests || i.notSent {
+		return true
+	}
+	if !idempotent {
+		return false
+	}
+	switch i.statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return i.transportErr != nil
+}
+
+// setTransportErr records an error of the HTTP transport, the request only never reached the
+// server if we failed to connect.
+func (i *requestInfo) setTransportErr(err error) {
+	var opErr *net.OpError
+	i.transportErr = err
+	i.notSent = errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// non200StatusCode matches the error returned by the GraphQL client for non-200 responses.
+var non200StatusCode = regexp.MustCompile(`^non-200 OK status code: (\d{3})\b`)
+
+// inferFrom fills the requestInfo from the error returned by the GraphQL client when the
+// retryTransport did not see the request, e.g. because mondoo-go uses its own HTTP client.
+// The Retry-After header and the GraphQL error paths are not available then.
+func (i *requestInfo) inferFrom(err error) {
+	if err == nil || i.statusCode != 0 || i.transportErr != nil {
+		return
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		i.setTransportErr(err)
+		return
+	}
+	if m := non200StatusCode.FindStringSubmatch(err.Error()); m != nil {
+		i.statusCode, _ = strconv.Atoi(m[1])
+	}
+}
+
+// retryTransport records the outcome of every GraphQL request into the requestInfo found
+// in the request context (if any).
+type retryTransport struct {
+	next http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+
+	info, ok := req.Context().Value(requestInfoKey{}).(*requestInfo)
+	if !ok {
+		return resp, err
+	}
+	if err != nil {
+		info.setTransportErr(err)
+		return resp, err
+	}
+	info.statusCode = resp.StatusCode
+	info.retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	info.errorPaths = graphqlErrorPaths(resp)
+	return resp, err
+}
+
+// parseRetryAfter parses the value of a Retry-After header, either in seconds or as HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// Query executes a GraphQL query, retrying on transient errors.
+func (c *ExtendedGqlClient) Query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
+	ctx, op := newGraphqlOperation(ctx, "query", q, variables)
+	attempt := 0
+	return c.withRetries(ctx, true, func(ctx context.Context) error {
+		start := time.Now()
+		err := c.Client.Query(ctx, q, variables)
+		op.trace(ctx, attempt, start, requestInfoFrom(ctx), err)
+		attempt++
+		return err
+	})
+}
+
+// Mutate executes a GraphQL mutation, mutations are not idempotent so we only retry them if
+// the server did not process the request.
+func (c *ExtendedGqlClient) Mutate(ctx context.Context, m interface{}, input mondoov1.Input, variables map[string]interface{}) error {
+	traceVariables := map[string]interface{}{}
+	for key, value := range variables {
+		traceVariables[key] = value
+	}
+	if input != nil {
+		traceVariables["input"] = input
+	}
+	ctx, op := newGraphqlOperation(ctx, "mutation", m, traceVariables)
+	if c.cache != nil {
+		// even failed mutations might have changed something
+		defer c.cache.invalidate(cacheInvalidations[op.name]...)
+	}
+	attempt := 0
+	return c.withRetries(ctx, false, func(ctx context.Context) error {
+		start := time.Now()
+		err := c.Client.Mutate(ctx, m, input, variables)
+		op.trace(ctx, attempt, start, requestInfoFrom(ctx), err)
+		attempt++
+		return err
+	})
+}
+
+// requestInfoFrom returns the requestInfo stored in the context, if any.
+func requestInfoFrom(ctx context.Context) *requestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(*requestInfo)
+	return info
+}
+
+func (c *ExtendedGqlClient) withRetries(ctx context.Context, idempotent bool, do func(context.Context) error) error {
+	for attempt := 0; ; attempt++ {
+		// only hold a slot while the request is in flight, not while waiting to retry
+		if err := c.limiter.acquire(ctx); err != nil {
+			return err
+		}
+		info := &requestInfo{}
+		err := do(context.WithValue(ctx, requestInfoKey{}, info))
+		c.limiter.release()
+		info.inferFrom(err)
+		if err == nil || attempt >= c.retry.maxRetries || !info.retryable(idempotent) {
+			return classifyAPIError(err, info)
+		}
+
+		delay := c.retry.backoff(attempt, info.retryAfter)
+		tflog.Debug(ctx, "retrying GraphQL request", map[string]interface{}{
+			"attempt":     attempt + 1,
+			"delay":       delay.String(),
+			"status_code": info.statusCode,
+			"error":       err.Error(),
+		})
+		select {
+		case <-ctx.Done():
+			return classifyAPIError(err, info)
+		case <-time.After(delay):
+		}
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Duration
+	}{
+		{
+			name:     "Empty header",
+			input:    "",
+			expected: 0,
+		},
+		{
+			name:     "Seconds",
+			input:    "120",
+			expected: 2 * time.Minute,
+		},
+		{
+			name:     "HTTP date",
+			input:    now.Add(30 * time.Second).Format(http.TimeFormat),
+			expected: 30 * time.Second,
+		},
+		{
+			name:     "HTTP date in the past",
+			input:    now.Add(-30 * time.Second).Format(http.TimeFormat),
+			expected: 0,
+		},
+		{
+			name:     "Invalid value",
+			input:    "soon",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseRetryAfter(tt.input, now))
+		})
+	}
+}
+
+func TestRequestInfoRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     requestInfo
+		query    bool
+		mutation bool
+	}{
+		{
+			name:     "Rate limited",
+			info:     requestInfo{statusCode: http.StatusTooManyRequests},
+			query:    true,
+			mutation: true,
+		},
+		{
+			name:     "Service unavailable",
+			info:     requestInfo{statusCode: http.StatusServiceUnavailable},
+			query:    true,
+			mutation: false,
+		},
+		{
+			name:     "Bad gateway",
+			info:     requestInfo{statusCode: http.StatusBadGateway},
+			query:    true,
+			mutation: false,
+		},
+		{
+			name:     "Internal server error",
+			info:     requestInfo{statusCode: http.StatusInternalServerError},
+			query:    false,
+			mutation: false,
+		},
+		{
+			name:     "GraphQL error",
+			info:     requestInfo{statusCode: http.StatusOK},
+			query:    false,
+			mutation: false,
+		},
+		{
+			name:     "Connection refused",
+			info:     requestInfo{transportErr: errors.New("connection refused"), notSent: true},
+			query:    true,
+			mutation: true,
+		},
+		{
+			name:     "Connection reset",
+			info:     requestInfo{transportErr: errors.New("connection reset by peer")},
+			query:    true,
+			mutation: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.query, tt.info.retryable(true))
+			assert.Equal(t, tt.mutation, tt.info.retryable(false))
+		})
+	}
+}
+
+func TestRequestInfoInferFrom(t *testing.T) {
+	dialErr := &url.Error{Op: "Post", URL: "https://proxy/", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+	resetErr := &url.Error{Op: "Post", URL: "https://proxy/", Err: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}}
+	tests := []struct {
+		name     string
+		info     requestInfo
+		err      error
+		expected requestInfo
+	}{
+		{
+			name:     "No error",
+			err:      nil,
+			expected: requestInfo{},
+		},
+		{
+			name:     "Non-200 status code",
+			err:      errors.New(`non-200 OK status code: 503 Service Unavailable body: "unavailable"`),
+			expected: requestInfo{statusCode: http.StatusServiceUnavailable},
+		},
+		{
+			name:     "Connection refused",
+			err:      dialErr,
+			expected: requestInfo{transportErr: dialErr, notSent: true},
+		},
+		{
+			name:     "Connection reset",
+			err:      fmt.Errorf("query failed: %w", resetErr),
+			expected: requestInfo{transportErr: fmt.Errorf("query failed: %w", resetErr)},
+		},
+		{
+			name:     "GraphQL error",
+			err:      errors.New(`space "//captain.api.mondoo.app/spaces/gone" not found`),
+			expected: requestInfo{},
+		},
+		{
+			name:     "Seen by the transport",
+			info:     requestInfo{statusCode: http.StatusTooManyRequests, retryAfter: time.Second},
+			err:      errors.New(`non-200 OK status code: 429 Too Many Requests body: ""`),
+			expected: requestInfo{statusCode: http.StatusTooManyRequests, retryAfter: time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.info.inferFrom(tt.err)
+			assert.Equal(t, tt.expected, tt.info)
+		})
+	}
+}
+
+func TestRetryConfigBackoff(t *testing.T) {
+	retry := retryConfig{maxRetries: 5, maxDelay: 4 * time.Second}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := retry.backoff(attempt, 0)
+		expected := min(baseRetryDelay<<attempt, retry.maxDelay)
+		assert.GreaterOrEqual(t, delay, expected/2)
+		assert.LessOrEqual(t, delay, expected)
+	}
+
+	// Retry-After wins, but never exceeds the maximum delay
+	assert.Equal(t, 2*time.Second, retry.backoff(0, 2*time.Second))
+	assert.Equal(t, retry.maxDelay, retry.backoff(0, time.Hour))
+}
+
+func TestRetryTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &retryTransport{next: http.DefaultTransport}}
+	info := &requestInfo{}
+	req, err := http.NewRequestWithContext(context.WithValue(context.Background(), requestInfoKey{}, info), http.MethodPost, server.URL, nil)
+	assert.NoError(t, err)
+
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, info.statusCode)
+	assert.Equal(t, time.Second, info.retryAfter)
+	assert.True(t, info.retryable(false))
+}
+
+func TestWithRetries(t *testing.T) {
+	client := &ExtendedGqlClient{retry: retryConfig{maxRetries: 2, maxDelay: time.Millisecond}}
+	failure := errors.New("rate limited")
+
+	t.Run("Retries until success", func(t *testing.T) {
+		calls := 0
+		err := client.withRetries(context.Background(), false, func(ctx context.Context) error {
+			calls++
+			if calls < 2 {
+				ctx.Value(requestInfoKey{}).(*requestInfo).statusCode = http.StatusTooManyRequests
+				return failure
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Gives up after max retries", func(t *testing.T) {
+		calls := 0
+		err := client.withRetries(context.Background(), true, func(ctx context.Context) error {
+			calls++
+			ctx.Value(requestInfoKey{}).(*requestInfo).statusCode = http.StatusServiceUnavailable
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Infers the status code from the error", func(t *testing.T) {
+		calls := 0
+		err := client.withRetries(context.Background(), true, func(ctx context.Context) error {
+			calls++
+			if calls < 2 {
+				return errors.New(`non-200 OK status code: 503 Service Unavailable body: ""`)
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Does not retry mutations that reached the server", func(t *testing.T) {
+		calls := 0
+		err := client.withRetries(context.Background(), false, func(ctx context.Context) error {
+			calls++
+			ctx.Value(requestInfoKey{}).(*requestInfo).statusCode = http.StatusServiceUnavailable
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestAccRetriedRequestsAreAuthenticated(t *testing.T) {
+	if fakeAPI == nil {
+		t.Skip("requires the fake Mondoo API")
+	}
+	orgID, err := getOrgId()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// the fake API rejects requests without the API token, so the retried
+				// queries only succeed if they still carry the credentials
+				PreConfig: func() { fakeAPI.failNextRequests(2) },
+				Config:    testAccOrganizationDataSourceConfig(orgID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mondoo_organization.org", "id", orgID),
+					func(*terraform.State) error {
+						if n := fakeAPI.pendingFailures(); n != 0 {
+							return fmt.Errorf("expected the failed requests to be retried, %d failures pending", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -6,14 +6,20 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	mondoov1 "go.mondoo.com/mondoo-go"
 )
 
-var _ resource.Resource = (*policyAssignmentResource)(nil)
+var (
+	_ resource.Resource                = (*scimGroupMappingResource)(nil)
+	_ resource.ResourceWithImportState = (*scimGroupMappingResource)(nil)
+)
 
 func NewScimGroupMappingResource() resource.Resource {
 	return &scimGroupMappingResource{}
@@ -150,7 +156,43 @@ func (r *scimGroupMappingResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	// Read API call logic
+	// the mappings are gone with the organization
+	orgMrn := orgPrefix + data.OrgID.ValueString()
+	org, err := r.client.GetOrganization(ctx, orgMrn)
+	if err == nil && org.Mrn == "" {
+		err = newNotFoundError("organization", orgMrn)
+	}
+	if removeIfNotFound(ctx, err, resp) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.
+			AddError("Client Error",
+				fmt.Sprintf("Unable to read organization. Got error: %s", err),
+			)
+		return
+	}
+
+	// reconcile with the mappings actually set, they might have been changed
+	// from the Mondoo Console
+	groups, err := r.client.GetScimGroupMappings(ctx, orgMrn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read SCIM group mappings. Got error: %s", err),
+		)
+		return
+	}
+	group := findScimGroupMapping(groups, data.Group.ValueString())
+	if group == nil {
+		// an empty mapping is how the mapping gets deleted
+		tflog.Warn(ctx, "SCIM group mapping not found, removing it from the state", map[string]interface{}{
+			"group": data.Group.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Mappings = reconcileScimGroupMappings(data.Mappings, group)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -223,3 +265,78 @@ func (r *scimGroupMappingResource) Delete(ctx context.Context, req resource.Dele
 	}
 
 }
+
+func (r *scimGroupMappingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgID, groupName, ok := strings.Cut(req.ID, "/")
+	if !ok || orgID == "" || groupName == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected `<org-id>/<group display name>`. Got: %q", req.ID),
+		)
+		return
+	}
+
+	groups, err := r.client.GetScimGroupMappings(ctx, orgPrefix+orgID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read SCIM group mappings. Got error: %s", err),
+		)
+		return
+	}
+	group := findScimGroupMapping(groups, groupName)
+	if group == nil {
+		resp.Diagnostics.AddError(
+			"Import Error",
+			fmt.Sprintf("No SCIM group mapping found for group %q in organization %s", groupName, orgID),
+		)
+		return
+	}
+
+	data := scimGroupMappingResourceModel{
+		OrgID:    types.StringValue(orgID),
+		Group:    types.StringValue(groupName),
+		Mappings: reconcileScimGroupMappings(nil, group),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findScimGroupMapping returns the mapping of the group, nil if the group is not mapped.
+func findScimGroupMapping(groups []ScimGroupMappingPayload, group string) *ScimGroupMappingPayload {
+	for i := range groups {
+		if groups[i].Group == group && len(groups[i].Mappings) > 0 {
+			return &groups[i]
+		}
+	}
+	return nil
+}
+
+// reconcileScimGroupMappings returns the mappings of the group, the ones that are still set keep
+// their order, so that the API returning them in a different order does not show up in plans.
+func reconcileScimGroupMappings(mappings []scimGroupMappingResourceMappingModel, group *ScimGroupMappingPayload) []scimGroupMappingResourceMappingModel {
+	actual := []scimGroupMappingResourceMappingModel{}
+	for _, m := range group.Mappings {
+		actual = append(actual, scimGroupMappingResourceMappingModel{
+			IamRole:  types.StringValue(m.IamRole),
+			SpaceMrn: RefreshStringValue(types.StringNull(), m.SpaceMrn),
+			OrgMrn:   RefreshStringValue(types.StringNull(), m.OrgMrn),
+		})
+	}
+
+	reconciled := []scimGroupMappingResourceMappingModel{}
+	for _, m := range mappings {
+		if i := slices.IndexFunc(actual, m.equal); i >= 0 {
+			reconciled = append(reconciled, m)
+			actual = slices.Delete(actual, i, i+1)
+		}
+	}
+	return append(reconciled, actual...)
+}
+
+// equal checks if both mappings grant the same role on the same scope.
+func (m scimGroupMappingResourceMappingModel) equal(other scimGroupMappingResourceMappingModel) bool {
+	return m.IamRole.ValueString() == other.IamRole.ValueString() &&
+		m.SpaceMrn.ValueString() == other.SpaceMrn.ValueString() &&
+		m.OrgMrn.ValueString() == other.OrgMrn.ValueString()
+}
@@ -0,0 +1,123 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/stretchr/testify/assert"
+	mondoov1 "go.mondoo.com/mondoo-go"
+)
+
+func TestReconcileScimGroupMappings(t *testing.T) {
+	viewer := scimGroupMappingResourceMappingModel{
+		IamRole:  types.StringValue("//iam.api.mondoo.app/roles/viewer"),
+		SpaceMrn: types.StringNull(),
+		OrgMrn:   types.StringValue("//captain.api.mondoo.app/organizations/lunalectric"),
+	}
+	editor := scimGroupMappingResourceMappingModel{
+		IamRole:  types.StringValue("//iam.api.mondoo.app/roles/editor"),
+		SpaceMrn: types.StringValue("//captain.api.mondoo.app/spaces/hungry-poet-123456"),
+		OrgMrn:   types.StringNull(),
+	}
+	payload := func(mappings ...scimGroupMappingResourceMappingModel) *ScimGroupMappingPayload {
+		group := &ScimGroupMappingPayload{Group: "Security"}
+		for _, m := range mappings {
+			group.Mappings = append(group.Mappings, struct {
+				IamRole  string
+				SpaceMrn string
+				OrgMrn   string
+			}{m.IamRole.ValueString(), m.SpaceMrn.ValueString(), m.OrgMrn.ValueString()})
+		}
+		return group
+	}
+
+	current := []scimGroupMappingResourceMappingModel{viewer, editor}
+	// the order of the API does not matter
+	assert.Equal(t, current, reconcileScimGroupMappings(current, payload(editor, viewer)))
+	// mappings changed in the console replace the ones that are gone
+	admin := scimGroupMappingResourceMappingModel{
+		IamRole:  types.StringValue("//iam.api.mondoo.app/roles/admin"),
+		SpaceMrn: types.StringNull(),
+		OrgMrn:   types.StringValue("//captain.api.mondoo.app/organizations/lunalectric"),
+	}
+	assert.Equal(t, []scimGroupMappingResourceMappingModel{editor, admin}, reconcileScimGroupMappings(current, payload(admin, editor)))
+	// imported mappings
+	assert.Equal(t, []scimGroupMappingResourceMappingModel{editor}, reconcileScimGroupMappings(nil, payload(editor)))
+}
+
+func TestAccScimGroupMappingResource(t *testing.T) {
+	orgID, err := getOrgId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccScimGroupMappingResourceConfig(orgID, "viewer"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mondoo_scim_group_mapping.test", "mappings.#", "1"),
+					resource.TestCheckResourceAttr("mondoo_scim_group_mapping.test", "mappings.0.iam_role", "//iam.api.mondoo.app/roles/viewer"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "mondoo_scim_group_mapping.test",
+				ImportState:                          true,
+				ImportStateId:                        orgID + "/Terraform Security Team",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "group",
+			},
+			// the role is changed from the Mondoo Console, Terraform sets it again
+			{
+				PreConfig: func() {
+					client, err := mondooClient()
+					if err != nil {
+						t.Fatal(err)
+					}
+					extendedC := ExtendedGqlClient{Client: client, retry: defaultRetryConfig}
+					err = extendedC.SetScimGroupMapping(context.Background(), orgPrefix+orgID, "Terraform Security Team", []mondoov1.ScimGroupMapping{{
+						IamRole: mondoov1.String("//iam.api.mondoo.app/roles/editor"),
+						OrgMrn:  mondoov1.NewStringPtr(mondoov1.String(orgPrefix + orgID)),
+					}})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccScimGroupMappingResourceConfig(orgID, "viewer"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mondoo_scim_group_mapping.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mondoo_scim_group_mapping.test", "mappings.0.iam_role", "//iam.api.mondoo.app/roles/viewer"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccScimGroupMappingResourceConfig(orgID string, role string) string {
+	return fmt.Sprintf(`
+resource "mondoo_scim_group_mapping" "test" {
+  org_id = %[1]q
+  group  = "Terraform Security Team"
+
+  mappings = [
+    {
+      org_mrn  = "//captain.api.mondoo.app/organizations/%[1]s"
+      iam_role = "//iam.api.mondoo.app/roles/%[2]s"
+    },
+  ]
+}
+`, orgID, role)
+}
@@ -0,0 +1,259 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mondoov1 "go.mondoo.com/mondoo-go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &ServiceAccountCredentialEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ServiceAccountCredentialEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &ServiceAccountCredentialEphemeralResource{}
+
+// serviceAccountPrivateKey is the private data key holding the service account to delete on close.
+const serviceAccountPrivateKey = "service_account"
+
+// serviceAccountPrivateData identifies the service account created by the ephemeral resource.
+type serviceAccountPrivateData struct {
+	Mrn      string `json:"mrn"`
+	ScopeMrn string `json:"scope_mrn"`
+}
+
+func NewServiceAccountCredentialEphemeralResource() ephemeral.EphemeralResource {
+	return &ServiceAccountCredentialEphemeralResource{}
+}
+
+// ServiceAccountCredentialEphemeralResource defines the ephemeral resource implementation.
+type ServiceAccountCredentialEphemeralResource struct {
+	client *ExtendedGqlClient
+}
+
+// ServiceAccountCredentialEphemeralResourceModel describes the ephemeral resource data model.
+type ServiceAccountCredentialEphemeralResourceModel struct {
+	// scope
+	SpaceID types.String `tfsdk:"space_id"`
+	OrgID   types.String `tfsdk:"org_id"`
+
+	// service account details
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	Roles         types.List   `tfsdk:"roles"`
+	DeleteOnClose types.Bool   `tfsdk:"delete_on_close"`
+
+	// output
+	Mrn        types.String `tfsdk:"mrn"`
+	Credential types.String `tfsdk:"credential"`
+}
+
+func (r *ServiceAccountCredentialEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_account_credential"
+}
+
+func (r *ServiceAccountCredentialEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Creates a short-lived service account and returns its credential without persisting it in the Terraform plan nor state.
+
+A new service account is created every time Terraform opens the ephemeral resource, this happens on every plan and apply.
+By default, the service account is deleted once Terraform no longer needs it, the credential is therefore only valid
+during the Terraform run, e.g. to configure another provider. Use the ` + "`mondoo_service_account`" + ` resource for
+credentials that are stored in another system.`,
+
+		Attributes: map[string]schema.Attribute{
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the Mondoo space in which to create the service account. If neither `space_id` nor `org_id` are set, the provider space is used.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("org_id")),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the Mondoo organization in which to create the service account.",
+				Optional:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the service account.",
+				Optional:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the service account, defaults to `Created by Terraform`.",
+				Optional:            true,
+			},
+			"roles": schema.ListAttribute{
+				MarkdownDescription: "Roles to assign to the service account, defaults to the viewer role.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"delete_on_close": schema.BoolAttribute{
+				MarkdownDescription: "Delete the service account once Terraform no longer needs the credential, defaults to `true`. When set to `false`, every plan and apply leaves a new service account behind, they must be cleaned up outside of Terraform.",
+				Optional:            true,
+			},
+			"mrn": schema.StringAttribute{
+				MarkdownDescription: "The Mondoo resource name (MRN) of the created service account.",
+				Computed:            true,
+			},
+			"credential": schema.StringAttribute{
+				MarkdownDescription: "The service account credential in JSON format, base64 encoded. This is the same content when creating service account credentials through the Mondoo Console.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *ServiceAccountCredentialEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ExtendedGqlClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ExtendedGqlClient. Got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ServiceAccountCredentialEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ServiceAccountCredentialEphemeralResourceModel
+
+	// Read Terraform config data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roles := []string{}
+	resp.Diagnostics.Append(data.Roles.ElementsAs(ctx, &roles, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(roles) == 0 {
+		roles = defaultRoles
+	}
+	rolesInput := []mondoov1.RoleInput{}
+	for _, role := range roles {
+		rolesInput = append(rolesInput, mondoov1.RoleInput{Mrn: mondoov1.String(role)})
+	}
+
+	description := "Created by Terraform"
+	if !data.Description.IsNull() {
+		description = data.Description.ValueString()
+	}
+
+	scopeMrn := serviceAccountScope(ctx, r.client, data.OrgID, data.SpaceID)
+	if scopeMrn == "" {
+		resp.Diagnostics.AddError(
+			"Either space_id or org_id needs to be set",
+			"Either space_id or org_id needs to be set",
+		)
+		return
+	}
+
+	serviceAccount, err := r.client.CreateServiceAccount(ctx, mondoov1.CreateServiceAccountInput{
+		Name:        mondoov1.NewStringPtr(mondoov1.String(data.Name.ValueString())),
+		Description: mondoov1.NewStringPtr(mondoov1.String(description)),
+		ScopeMrn:    mondoov1.String(scopeMrn),
+		Roles:       &rolesInput,
+	})
+	if err != nil {
+		resp.Diagnostics.
+			AddError("Client Error",
+				fmt.Sprintf("Unable to create service account. Got error: %s", err),
+			)
+		return
+	}
+
+	// Close is not called when Open fails, the service account must not outlive a failed Open
+	deleteServiceAccount := func() {
+		if err := r.client.DeleteServiceAccount(ctx, scopeMrn, string(serviceAccount.Mrn)); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Unable to delete service account",
+				fmt.Sprintf("The service account %s was created but could not be deleted, delete it from the Mondoo Console. Got error: %s", serviceAccount.Mrn, err),
+			)
+		}
+	}
+
+	credential, err := serviceAccount.credential()
+	if err != nil {
+		resp.Diagnostics.
+			AddError("Client Error",
+				fmt.Sprintf("Unable to create service account. Got error: %s", err),
+			)
+		deleteServiceAccount()
+		return
+	}
+
+	if !data.DeleteOnClose.IsNull() && !data.DeleteOnClose.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Service account is not deleted",
+			fmt.Sprintf("The service account %s is kept since delete_on_close is false, a new service account is created on every plan and apply. "+
+				"Use the mondoo_service_account resource for long-lived credentials.", serviceAccount.Mrn),
+		)
+	} else {
+		// remember which service account to delete once Terraform is done with it
+		privateData, err := json.Marshal(serviceAccountPrivateData{
+			Mrn:      string(serviceAccount.Mrn),
+			ScopeMrn: scopeMrn,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to store service account MRN. Got error: %s", err))
+			deleteServiceAccount()
+			return
+		}
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, serviceAccountPrivateKey, privateData)...)
+		if resp.Diagnostics.HasError() {
+			deleteServiceAccount()
+			return
+		}
+	}
+
+	data.Mrn = types.StringValue(string(serviceAccount.Mrn))
+	data.Credential = types.StringValue(credential)
+
+	tflog.Debug(ctx, "opened a service account credential ephemeral resource")
+
+	// Save data into the ephemeral result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *ServiceAccountCredentialEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	value, diags := req.Private.GetKey(ctx, serviceAccountPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || value == nil {
+		// the service account should be kept
+		return
+	}
+
+	var serviceAccount serviceAccountPrivateData
+	if err := json.Unmarshal(value, &serviceAccount); err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to read service account MRN. Got error: %s", err))
+		return
+	}
+
+	err := r.client.DeleteServiceAccount(ctx, serviceAccount.ScopeMrn, serviceAccount.Mrn)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete service account. Got error: %s", err))
+		return
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccServiceAccountCredentialEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceAccountCredentialEphemeralResourceConfig(accSpace.ID(), `delete_on_close = false`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.name", "ephemeral"),
+					resource.TestMatchResourceAttr("echo.test", "data.mrn", regexp.MustCompile(`/serviceaccounts/`)),
+					resource.TestCheckResourceAttrSet("echo.test", "data.credential"),
+					testAccCheckServiceAccountExists("echo.test", true),
+				),
+			},
+			{
+				Config: testAccServiceAccountCredentialEphemeralResourceConfig(accSpace.ID(), ``),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("echo.test", "data.credential"),
+					testAccCheckServiceAccountExists("echo.test", false),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckServiceAccountExists checks if the service account was deleted when the ephemeral
+// resource was closed, we inspect the fake Mondoo API since the credential can't be used anymore.
+func testAccCheckServiceAccountExists(resourceName string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if fakeAPI == nil {
+			return nil
+		}
+		mrn := s.RootModule().Resources[resourceName].Primary.Attributes["data.mrn"]
+
+		fakeAPI.mu.Lock()
+		defer fakeAPI.mu.Unlock()
+		if _, ok := fakeAPI.serviceAccounts[mrn]; ok != exists {
+			return fmt.Errorf("expected service account %s to exist: %t", mrn, exists)
+		}
+		return nil
+	}
+}
+
+func testAccServiceAccountCredentialEphemeralResourceConfig(spaceID, options string) string {
+	return fmt.Sprintf(`
+ephemeral "mondoo_service_account_credential" "test" {
+  space_id = %[1]q
+  name     = "ephemeral"
+  %[2]s
+}
+
+provider "echo" {
+  data = ephemeral.mondoo_service_account_credential.test
+}
+
+resource "echo" "test" {}
+`, spaceID, options)
+}
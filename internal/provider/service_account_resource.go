@@ -10,7 +10,6 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
@@ -24,8 +23,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ServiceAccountResource{}
-
-//var _ resource.ResourceWithImportState = &ServiceAccountResource{}
+var _ resource.ResourceWithImportState = &ServiceAccountResource{}
 
 var defaultRoles = []string{"//iam.api.mondoo.app/roles/viewer"}
 
@@ -121,7 +119,7 @@ func (r *ServiceAccountResource) Schema(ctx context.Context, req resource.Schema
 			},
 			"credential": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "The service account credential in JSON format, base64 encoded. This is the same content when creating service account credentials through the Mondoo Console.",
+				MarkdownDescription: "The service account credential in JSON format, base64 encoded. This is the same content when creating service account credentials through the Mondoo Console. It is only available for service accounts created by Terraform, not for imported ones.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -152,12 +150,17 @@ func (r *ServiceAccountResource) Configure(ctx context.Context, req resource.Con
 }
 
 func (r *ServiceAccountResource) getScope(ctx context.Context, data ServiceAccountResourceModel) string {
+	return serviceAccountScope(ctx, r.client, data.OrgID, data.SpaceID)
+}
+
+// serviceAccountScope returns the MRN of the organization or space where a service account lives.
+func serviceAccountScope(ctx context.Context, client *ExtendedGqlClient, orgID, spaceID types.String) string {
 	scopeMrn := ""
 	// Give presedence to the org id
-	if data.OrgID.ValueString() != "" {
-		scopeMrn = orgPrefix + data.OrgID.ValueString()
+	if orgID.ValueString() != "" {
+		scopeMrn = orgPrefix + orgID.ValueString()
 		ctx = tflog.SetField(ctx, "org_mrn", scopeMrn)
-	} else if space, err := r.client.ComputeSpace(data.SpaceID); err == nil {
+	} else if space, err := client.ComputeSpace(spaceID); err == nil {
 		scopeMrn = space.MRN()
 		ctx = tflog.SetField(ctx, "space_mrn", scopeMrn)
 	}
@@ -165,6 +168,25 @@ func (r *ServiceAccountResource) getScope(ctx context.Context, data ServiceAccou
 	return scopeMrn
 }
 
+// credential returns the base64 encoded credential of a newly created service account.
+func (p createServiceAccountPayload) credential() (string, error) {
+	// NOTE: this is temporary, we want to change the API to return the credential as a string
+	serviceAccount := serviceAccountCredential{
+		Mrn:         string(p.Mrn),
+		PrivateKey:  string(p.PrivateKey),
+		Certificate: string(p.Certificate),
+		ApiEndpoint: string(p.ApiEndpoint),
+		ScopeMrn:    string(p.ScopeMrn),
+		ParentMrn:   string(p.ScopeMrn),
+	}
+
+	jsonData, err := json.Marshal(serviceAccount)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(jsonData), nil
+}
+
 func (r *ServiceAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ServiceAccountResourceModel
 
@@ -215,21 +237,7 @@ func (r *ServiceAccountResource) Create(ctx context.Context, req resource.Create
 		Roles:       &rolesInput,
 	}
 
-	tflog.Debug(ctx, "CreateSpaceInput", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", createInput),
-	})
-
-	var createMutation struct {
-		CreateServiceAccount struct {
-			Mrn         mondoov1.String
-			Certificate mondoov1.String
-			PrivateKey  mondoov1.String
-			ScopeMrn    mondoov1.String
-			ApiEndpoint mondoov1.String
-		} `graphql:"createServiceAccount(input: $input)"`
-	}
-
-	err := r.client.Mutate(ctx, &createMutation, createInput, nil)
+	serviceAccount, err := r.client.CreateServiceAccount(ctx, createInput)
 	if err != nil {
 		resp.Diagnostics.
 			AddError("Client Error",
@@ -240,19 +248,9 @@ func (r *ServiceAccountResource) Create(ctx context.Context, req resource.Create
 
 	// Save space mrn into the Terraform state.
 	data.Name = types.StringValue(name)
-	data.Mrn = types.StringValue(string(createMutation.CreateServiceAccount.Mrn))
-
-	// NOTE: this is temporary, we want to change the API to return the credential as a string
-	serviceAccount := serviceAccountCredential{
-		Mrn:         string(createMutation.CreateServiceAccount.Mrn),
-		PrivateKey:  string(createMutation.CreateServiceAccount.PrivateKey),
-		Certificate: string(createMutation.CreateServiceAccount.Certificate),
-		ApiEndpoint: string(createMutation.CreateServiceAccount.ApiEndpoint),
-		ScopeMrn:    string(createMutation.CreateServiceAccount.ScopeMrn),
-		ParentMrn:   string(createMutation.CreateServiceAccount.ScopeMrn),
-	}
+	data.Mrn = types.StringValue(string(serviceAccount.Mrn))
 
-	jsonData, err := json.Marshal(serviceAccount)
+	credential, err := serviceAccount.credential()
 	if err != nil {
 		resp.Diagnostics.
 			AddError("Client Error",
@@ -262,7 +260,7 @@ func (r *ServiceAccountResource) Create(ctx context.Context, req resource.Create
 	}
 
 	// set base 64 encoded credential
-	data.Credential = types.StringValue(base64.StdEncoding.EncodeToString(jsonData))
+	data.Credential = types.StringValue(credential)
 
 	// Write logs using the tflog package
 	tflog.Debug(ctx, "created a service account resource")
@@ -296,16 +294,32 @@ func (r *ServiceAccountResource) readServiceAccount(ctx context.Context, mrn str
 		return ServiceAccountResourceModel{}, err
 	}
 
+	roles := []string{}
+	for _, role := range q.ServiceAccount.Roles {
+		roles = append(roles, role.Mrn)
+	}
+
 	return ServiceAccountResourceModel{
 		Mrn:         types.StringValue(q.ServiceAccount.Mrn),
 		Name:        types.StringValue(q.ServiceAccount.Name),
 		Description: types.StringValue(q.ServiceAccount.Description),
-		// TODO: add roles
-		//SpaceID: types.StringValue(q.ServiceAccount.Id),
-		//OrgID:   types.StringValue(q.Space.Organization.Id),
+		Roles:       ConvertListValue(roles),
 	}, nil
 }
 
+// refreshServiceAccountRoles returns the roles read from the API, the roles that are still
+// assigned keep their order, so that the API returning them in a different order does not
+// show up in plans.
+func refreshServiceAccountRoles(ctx context.Context, prior types.List, actual types.List) (types.List, diag.Diagnostics) {
+	priorRoles, roles := []string{}, []string{}
+	diags := prior.ElementsAs(ctx, &priorRoles, false)
+	diags.Append(actual.ElementsAs(ctx, &roles, false)...)
+	if diags.HasError() {
+		return prior, diags
+	}
+	return RefreshListValue(prior, append(intersect(priorRoles, roles), subtract(roles, priorRoles)...)), diags
+}
+
 func (r *ServiceAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data ServiceAccountResourceModel
 
@@ -317,6 +331,12 @@ func (r *ServiceAccountResource) Read(ctx context.Context, req resource.ReadRequ
 	}
 
 	m, err := r.readServiceAccount(ctx, data.Mrn.ValueString())
+	if err == nil && m.Mrn.ValueString() == "" {
+		err = newNotFoundError("service account", data.Mrn.ValueString())
+	}
+	if removeIfNotFound(ctx, err, resp) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
@@ -328,6 +348,9 @@ func (r *ServiceAccountResource) Read(ctx context.Context, req resource.ReadRequ
 	data.Mrn = m.Mrn
 	data.Name = m.Name
 	data.Description = m.Description
+	var diags diag.Diagnostics
+	data.Roles, diags = refreshServiceAccountRoles(ctx, data.Roles, m.Roles)
+	resp.Diagnostics.Append(diags...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -343,6 +366,16 @@ func (r *ServiceAccountResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	roles := []string{}
+	resp.Diagnostics.Append(data.Roles.ElementsAs(ctx, &roles, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	rolesInput := []mondoov1.RoleInput{}
+	for _, role := range roles {
+		rolesInput = append(rolesInput, mondoov1.RoleInput{Mrn: mondoov1.String(role)})
+	}
+
 	// Do GraphQL request to API to update the resource.
 	var updateMutation struct {
 		UpdateServiceAccount struct {
@@ -355,18 +388,18 @@ func (r *ServiceAccountResource) Update(ctx context.Context, req resource.Update
 		Mrn:   mondoov1.String(data.Mrn.ValueString()),
 		Name:  mondoov1.NewStringPtr(mondoov1.String(data.Name.ValueString())),
 		Notes: mondoov1.NewStringPtr(mondoov1.String(data.Description.ValueString())),
+		Roles: &rolesInput,
 	}
-	tflog.Debug(ctx, "UpdateServiceAccountInput", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", updateInput),
-	})
 	err := r.client.Mutate(ctx, &updateMutation, updateInput, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update service account. Got error: %s", err))
 		return
 	}
 
+	data.Name = types.StringValue(string(updateMutation.UpdateServiceAccount.Name))
+
 	// Save updated data into Terraform state
-	resp.State.SetAttribute(ctx, path.Root("name"), updateMutation.UpdateServiceAccount.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ServiceAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -389,24 +422,45 @@ func (r *ServiceAccountResource) Delete(ctx context.Context, req resource.Delete
 	}
 
 	// Do GraphQL request to API to delete the resource.
-	var deleteMutation struct {
-		DeleteServiceAccounts struct {
-			Mrns []mondoov1.String
-		} `graphql:"deleteServiceAccounts(input: $input)"`
-	}
-	deleteInput := mondoov1.DeleteServiceAccountsInput{
-		ScopeMrn: mondoov1.String(scopeMrn),
-		Mrns:     []mondoov1.String{mondoov1.String(data.Mrn.ValueString())},
-	}
-	tflog.Debug(ctx, "UpdateServiceAccountInput", map[string]interface{}{
-		"input": fmt.Sprintf("%+v", deleteInput),
-	})
-	err := r.client.Mutate(ctx, &deleteMutation, deleteInput, nil)
+	err := r.client.DeleteServiceAccount(ctx, scopeMrn, data.Mrn.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update service account. Got error: %s", err))
 		return
 	}
 }
 
-// We do not allow users to import a service account resource.
-// func (r *ServiceAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {}
+// ImportState imports a service account by its MRN. The credential is only returned when the
+// service account is created, it stays empty for imported service accounts.
+func (r *ServiceAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	mrn, err := ParseMrn(req.ID)
+	if err == nil && mrn.Kind != "serviceaccounts" {
+		err = fmt.Errorf("invalid MRN %q, expected a service account MRN", req.ID)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	data, err := r.readServiceAccount(ctx, req.ID)
+	if err == nil && data.Mrn.ValueString() == "" {
+		err = newNotFoundError("service account", req.ID)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read service account. Got error: %s", err),
+		)
+		return
+	}
+
+	data.SpaceID = types.StringNull()
+	data.OrgID = types.StringNull()
+	if spaceID, ok := mrn.IDs["spaces"]; ok {
+		data.SpaceID = types.StringValue(spaceID)
+	} else if orgID, ok := mrn.IDs["organizations"]; ok {
+		data.OrgID = types.StringValue(orgID)
+	}
+	data.Credential = types.StringNull()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -4,17 +4,44 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mondoov1 "go.mondoo.com/mondoo-go"
 )
 
+func TestRefreshServiceAccountRoles(t *testing.T) {
+	ctx := context.Background()
+	viewer := "//iam.api.mondoo.app/roles/viewer"
+	editor := "//iam.api.mondoo.app/roles/editor"
+	agent := "//iam.api.mondoo.app/roles/agent"
+
+	roles, diags := refreshServiceAccountRoles(ctx, ConvertListValue([]string{viewer, agent}), ConvertListValue([]string{agent, viewer}))
+	require.False(t, diags.HasError())
+	assert.Equal(t, ConvertListValue([]string{viewer, agent}), roles)
+
+	roles, diags = refreshServiceAccountRoles(ctx, ConvertListValue([]string{viewer, agent}), ConvertListValue([]string{editor, agent}))
+	require.False(t, diags.HasError())
+	assert.Equal(t, ConvertListValue([]string{agent, editor}), roles)
+
+	roles, diags = refreshServiceAccountRoles(ctx, types.ListNull(types.StringType), ConvertListValue([]string{}))
+	require.False(t, diags.HasError())
+	assert.True(t, roles.IsNull())
+}
+
 func TestAccServiceAccountResource(t *testing.T) {
 	orgID, err := getOrgId()
 	if err != nil {
 		t.Fatal(err)
 	}
+	var serviceAccountMrn string
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -30,15 +57,51 @@ func TestAccServiceAccountResource(t *testing.T) {
 				Config: testAccServiceAccountSpaceResourceConfig(orgID, "one"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("mondoo_service_account.space", "name", "one"),
+					func(s *terraform.State) error {
+						serviceAccountMrn = s.RootModule().Resources["mondoo_service_account.space"].Primary.Attributes["mrn"]
+						return nil
+					},
 				),
 			},
 			// ImportState testing
-			// service accounts cannot be imported
-			//{
-			//	ResourceName:      "mondoo_service_account.test",
-			//	ImportState:       true,
-			//	ImportStateVerify: true,
-			//},
+			{
+				ResourceName: "mondoo_service_account.space",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["mondoo_service_account.space"].Primary.Attributes["mrn"], nil
+				},
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "mrn",
+				// the credential cannot be recovered
+				ImportStateVerifyIgnore: []string{"credential"},
+			},
+			// the roles are changed from the Mondoo Console, Terraform sets them again
+			{
+				PreConfig: func() {
+					client, err := mondooClient()
+					if err != nil {
+						t.Fatal(err)
+					}
+					err = client.Mutate(context.Background(), &struct {
+						UpdateServiceAccount struct {
+							Mrn mondoov1.String
+						} `graphql:"updateServiceAccount(input: $input)"`
+					}{}, mondoov1.UpdateServiceAccountInput{
+						Mrn:   mondoov1.String(serviceAccountMrn),
+						Roles: &[]mondoov1.RoleInput{{Mrn: "//iam.api.mondoo.app/roles/editor"}},
+					}, nil)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccServiceAccountSpaceResourceConfig(orgID, "one"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mondoo_service_account.space", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mondoo_service_account.space", "roles.0", "//iam.api.mondoo.app/roles/viewer"),
+			},
 			// Update and Read testing
 			{
 				Config: testAccServiceAccountOrgResourceConfig(orgID, "two"),
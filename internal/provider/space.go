@@ -3,7 +3,10 @@
 
 package provider
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 const spacePrefix = "//captain.api.mondoo.app/spaces/"
 
@@ -22,6 +25,25 @@ func SpaceFrom(space string) Space {
 	return Space(space)
 }
 
+// ParseSpace receives either a space id or a space mrn and returns a `Space`, unlike SpaceFrom it
+// fails when the value is neither of them.
+func ParseSpace(space string) (Space, error) {
+	if strings.Contains(space, "/") {
+		mrn, err := ParseMrn(space)
+		if err != nil {
+			return "", err
+		}
+		if mrn.Kind != "spaces" || spacePrefix+mrn.ID() != space {
+			return "", fmt.Errorf("%q is not the MRN of a space", space)
+		}
+		return Space(mrn.ID()), nil
+	}
+	if space == "" || strings.ContainsAny(space, "/, ") {
+		return "", fmt.Errorf("invalid space id %q", space)
+	}
+	return Space(space), nil
+}
+
 func (s Space) ID() string {
 	return string(s)
 }
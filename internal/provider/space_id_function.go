@@ -0,0 +1,54 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SpaceIdFunction{}
+
+func NewSpaceIdFunction() function.Function {
+	return &SpaceIdFunction{}
+}
+
+// SpaceIdFunction defines the function implementation.
+type SpaceIdFunction struct{}
+
+func (f *SpaceIdFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "space_id"
+}
+
+func (f *SpaceIdFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the id of a space",
+		MarkdownDescription: "Returns the unique identifier of the space with the given Mondoo Resource Name (MRN).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "space_mrn",
+				MarkdownDescription: "The unique Mondoo Resource Name (MRN) of the space.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SpaceIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var spaceMrn string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &spaceMrn))
+	if resp.Error != nil {
+		return
+	}
+
+	space, err := ParseSpace(spaceMrn)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, space.ID()))
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SpaceMrnFunction{}
+
+func NewSpaceMrnFunction() function.Function {
+	return &SpaceMrnFunction{}
+}
+
+// SpaceMrnFunction defines the function implementation.
+type SpaceMrnFunction struct{}
+
+func (f *SpaceMrnFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "space_mrn"
+}
+
+func (f *SpaceMrnFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the MRN of a space",
+		MarkdownDescription: "Returns the Mondoo Resource Name (MRN) of the space with the given id.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "space_id",
+				MarkdownDescription: "The unique identifier of the space.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SpaceMrnFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var spaceID string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &spaceID))
+	if resp.Error != nil {
+		return
+	}
+
+	if spaceID == "" || strings.Contains(spaceID, "/") {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid space id "+spaceID)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, SpaceFrom(spaceID).MRN()))
+}
@@ -172,7 +172,16 @@ func (r *SpaceResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	// nothing to do here, we already have the data in the state
+
+	// the space might have been renamed or deleted outside of Terraform
+	space, ok := r.client.ReadSpace(ctx, SpaceFrom(data.SpaceID.ValueString()).MRN(), resp)
+	if !ok {
+		return
+	}
+
+	data.Name = types.StringValue(space.Name)
+	data.SpaceMrn = types.StringValue(space.Mrn)
+	data.OrgID = types.StringValue(space.Organization.Id)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -4,11 +4,14 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccSpaceResource(t *testing.T) {
@@ -47,6 +50,65 @@ func TestAccSpaceResource(t *testing.T) {
 	})
 }
 
+func TestAccSpaceResourceChangedOutsideTerraform(t *testing.T) {
+	orgID, err := getOrgId()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var spaceID string
+	outsideTerraform := func(change func(c ExtendedGqlClient) error) func() {
+		return func() {
+			client, err := mondooClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := change(ExtendedGqlClient{Client: client, retry: defaultRetryConfig}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpaceResourceConfig(orgID, "vanishing"),
+				Check: func(s *terraform.State) error {
+					spaceID = s.RootModule().Resources["mondoo_space.test"].Primary.ID
+					return nil
+				},
+			},
+			// the space is renamed from the Mondoo Console, Terraform renames it back
+			{
+				PreConfig: outsideTerraform(func(c ExtendedGqlClient) error {
+					return c.UpdateSpace(context.Background(), spaceID, "renamed")
+				}),
+				Config: testAccSpaceResourceConfig(orgID, "vanishing"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mondoo_space.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mondoo_space.test", "name", "vanishing"),
+			},
+			// the space is deleted from the Mondoo Console, Terraform creates it again
+			{
+				PreConfig: outsideTerraform(func(c ExtendedGqlClient) error {
+					return c.DeleteSpace(context.Background(), spaceID)
+				}),
+				Config: testAccSpaceResourceConfig(orgID, "vanishing"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mondoo_space.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
 func testAccSpaceResourceConfig(resourceOrgID string, name string) string {
 	return fmt.Sprintf(`
 resource "mondoo_space" "test" {
@@ -55,6 +55,63 @@ func TestSpaceFrom(t *testing.T) {
 	}
 }
 
+func TestParseSpace(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Space
+		wantErr  bool
+	}{
+		{
+			name:     "Valid MRN",
+			input:    "//captain.api.mondoo.app/spaces/1234",
+			expected: Space("1234"),
+		},
+		{
+			name:     "Space ID only",
+			input:    "5678",
+			expected: Space("5678"),
+		},
+		{
+			name:    "MRN with additional segments",
+			input:   "//captain.api.mondoo.app/spaces/1234/resources/5678",
+			wantErr: true,
+		},
+		{
+			name:    "MRN without space ID segment",
+			input:   "//captain.api.mondoo.app/spaces/",
+			wantErr: true,
+		},
+		{
+			name:    "Organization MRN",
+			input:   "//captain.api.mondoo.app/organizations/1234",
+			wantErr: true,
+		},
+		{
+			name:    "Empty string input",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "Path",
+			input:   "1234/5678",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSpace(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestSpace_ID(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,172 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const traceVariablesPrefix = "graphql_variables"
+
+var (
+	// secretFieldRegex matches the names of the GraphQL fields holding secrets, their values are
+	// masked in the logs, e.g. `token`, `slackToken`, `privateKey` or `client_secret`
+	secretFieldRegex = regexp.MustCompile(`(?i)(token|secret|password|passphrase|private_?key|api_?key|credential)`)
+
+	// pemRegex matches PEM contents, like certificates and keys, wherever they appear
+	pemRegex = regexp.MustCompile(`-----BEGIN [A-Z0-9 ]+-----[\s\S]*?-----END [A-Z0-9 ]+-----`)
+)
+
+// graphqlOperation describes a GraphQL operation for tracing purposes.
+type graphqlOperation struct {
+	kind   string
+	name   string
+	fields map[string]interface{}
+}
+
+// newGraphqlOperation describes the query or mutation q, the variables (and mutation input) are
+// flattened into individual log fields so that tflog can mask the ones holding secrets. It returns
+// the context with the masks configured, use it to log anything about the operation.
+func newGraphqlOperation(ctx context.Context, kind string, q interface{}, variables map[string]interface{}) (context.Context, graphqlOperation) {
+	op := graphqlOperation{
+		kind:   kind,
+		name:   graphqlOperationName(q),
+		fields: map[string]interface{}{},
+	}
+
+	secrets := []string{}
+	if len(variables) > 0 {
+		// variables are GraphQL scalars and input objects, they all support JSON
+		if data, err := json.Marshal(variables); err == nil {
+			var decoded interface{}
+			if err := json.Unmarshal(data, &decoded); err == nil {
+				flattenTraceFields(traceVariablesPrefix, "", decoded, op.fields, &secrets)
+			}
+		}
+	}
+
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, secrets...)
+	ctx = tflog.MaskAllFieldValuesRegexes(ctx, pemRegex)
+	return ctx, op
+}
+
+// flattenTraceFields adds every scalar in value to fields, keyed by its path, the paths of the
+// values stored in secret fields are added to secrets.
+func flattenTraceFields(path string, name string, value interface{}, fields map[string]interface{}, secrets *[]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			flattenTraceFields(path+"."+key, key, nested, fields, secrets)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			// list elements inherit the name of the field, e.g. a list of tokens
+			flattenTraceFields(path+"."+strconv.Itoa(i), name, nested, fields, secrets)
+		}
+	case nil:
+		// skip unset fields, they only add noise
+	default:
+		fields[path] = v
+		if secretFieldRegex.MatchString(name) {
+			*secrets = append(*secrets, path)
+		}
+	}
+}
+
+// graphqlOperationName returns the name of the fields requested by the query or mutation q,
+// like the GraphQL client does, using the `graphql` tag or the field name.
+func graphqlOperationName(q interface{}) string {
+	t := reflect.TypeOf(q)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return ""
+	}
+
+	names := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("graphql")
+		if name == "" {
+			runes := []rune(field.Name)
+			runes[0] = unicode.ToLower(runes[0])
+			name = string(runes)
+		}
+		if idx := strings.Index(name, "("); idx >= 0 {
+			name = name[:idx]
+		}
+		names = append(names, strings.TrimSpace(name))
+	}
+	return strings.Join(names, ",")
+}
+
+// trace emits a trace entry for one attempt of the operation.
+func (op graphqlOperation) trace(ctx context.Context, attempt int, start time.Time, info *requestInfo, err error) {
+	fields := map[string]interface{}{
+		"graphql_operation":      op.name,
+		"graphql_operation_type": op.kind,
+		"attempt":                attempt + 1,
+		"duration":               time.Since(start).String(),
+	}
+	for key, value := range op.fields {
+		fields[key] = value
+	}
+	if info != nil {
+		fields["status_code"] = info.statusCode
+		if len(info.errorPaths) > 0 {
+			fields["graphql_error_paths"] = info.errorPaths
+		}
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	tflog.Trace(ctx, fmt.Sprintf("GraphQL %s %s", op.kind, op.name), fields)
+}
+
+// graphqlErrorPaths returns the paths of the GraphQL errors found in the response, if any. The
+// body of the response is restored so that the GraphQL client can still read it.
+func graphqlErrorPaths(resp *http.Response) []string {
+	if resp.Body == nil {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil || !bytes.Contains(body, []byte(`"errors"`)) {
+		return nil
+	}
+
+	var payload struct {
+		Errors []struct {
+			Path []interface{} `json:"path"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+
+	paths := []string{}
+	for _, e := range payload.Errors {
+		segments := []string{}
+		for _, segment := range e.Path {
+			segments = append(segments, fmt.Sprint(segment))
+		}
+		paths = append(paths, strings.Join(segments, "."))
+	}
+	return paths
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mondoov1 "go.mondoo.com/mondoo-go"
+)
+
+func TestGraphqlOperationName(t *testing.T) {
+	var mutation struct {
+		CreateSpace struct {
+			Mrn mondoov1.String
+		} `graphql:"createSpace(input: $input)"`
+	}
+	assert.Equal(t, "createSpace", graphqlOperationName(&mutation))
+
+	var query struct {
+		Organization struct {
+			Name mondoov1.String
+		}
+		Space struct {
+			Name mondoov1.String
+		} `graphql:"space(mrn: $mrn)"`
+	}
+	assert.Equal(t, "organization,space", graphqlOperationName(&query))
+
+	assert.Equal(t, "", graphqlOperationName(nil))
+}
+
+func TestGraphqlOperationTrace(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	var mutation struct {
+		CreateClientIntegration struct {
+			Mrn mondoov1.String
+		} `graphql:"createClientIntegration(input: $input)"`
+	}
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"name":     "slack",
+			"spaceMrn": "//captain.api.mondoo.app/spaces/hungry-poet-123456",
+			"configurationOptions": map[string]interface{}{
+				"slackConfigurationOptions": map[string]interface{}{
+					"slackToken": "xoxa-2-secret",
+				},
+				"ociConfigurationOptions": map[string]interface{}{
+					"privateKey":  "private",
+					"fingerprint": "-----BEGIN PUBLIC KEY-----\nABC\n-----END PUBLIC KEY-----",
+				},
+			},
+			"tokens": []string{"first-secret", "second-secret"},
+			"notes":  nil,
+		},
+	}
+
+	ctx, op := newGraphqlOperation(ctx, "mutation", &mutation, variables)
+	op.trace(ctx, 0, time.Now(), &requestInfo{statusCode: http.StatusOK, errorPaths: []string{"createClientIntegration"}}, errors.New("invalid space"))
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	entry := entries[0]
+
+	assert.Equal(t, "GraphQL mutation createClientIntegration", entry["@message"])
+	assert.Equal(t, "createClientIntegration", entry["graphql_operation"])
+	assert.Equal(t, "slack", entry["graphql_variables.input.name"])
+	assert.Equal(t, "//captain.api.mondoo.app/spaces/hungry-poet-123456", entry["graphql_variables.input.spaceMrn"])
+	assert.Equal(t, float64(http.StatusOK), entry["status_code"])
+	assert.Equal(t, []interface{}{"createClientIntegration"}, entry["graphql_error_paths"])
+	assert.Equal(t, "invalid space", entry["error"])
+	assert.NotContains(t, entry, "graphql_variables.input.notes")
+
+	// secrets are masked
+	assert.Equal(t, "***", entry["graphql_variables.input.configurationOptions.slackConfigurationOptions.slackToken"])
+	assert.Equal(t, "***", entry["graphql_variables.input.configurationOptions.ociConfigurationOptions.privateKey"])
+	assert.Equal(t, "***", entry["graphql_variables.input.configurationOptions.ociConfigurationOptions.fingerprint"])
+	assert.Equal(t, "***", entry["graphql_variables.input.tokens.0"])
+	assert.Equal(t, "***", entry["graphql_variables.input.tokens.1"])
+	assert.NotContains(t, output.String(), "secret")
+}
+
+func TestGraphqlErrorPaths(t *testing.T) {
+	body := `{"data":{"space":null},"errors":[{"message":"space not found","path":["space"]},{"message":"denied","path":["space","policies",0]}]}`
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+
+	assert.Equal(t, []string{"space", "space.policies.0"}, graphqlErrorPaths(resp))
+
+	// the body can still be read by the GraphQL client
+	restored, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(restored))
+
+	assert.Nil(t, graphqlErrorPaths(&http.Response{Body: io.NopCloser(strings.NewReader(`{"data":{"space":{}}}`))}))
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// transportConfig configures how the provider connects to the Mondoo API, it is required to
+// reach self-hosted installations behind proxies or using a private certificate authority.
+type transportConfig struct {
+	// proxy is the URL of the HTTP(S) proxy, if not set, the standard HTTPS_PROXY, HTTP_PROXY
+	// and NO_PROXY environment variables are honored
+	proxy string
+	// caFile is a PEM bundle with additional certificate authorities to trust
+	caFile string
+	// clientCertFile and clientKeyFile are the PEM encoded client certificate and key for mTLS
+	clientCertFile string
+	clientKeyFile  string
+	// tlsServerName overrides the server name used to verify the certificate of the API
+	tlsServerName string
+}
+
+// configured checks if any connection setting is set, otherwise mondoo-go uses its own HTTP client.
+func (c transportConfig) configured() bool {
+	return c != transportConfig{}
+}
+
+// httpTransport returns the HTTP transport to communicate with the Mondoo API.
+func (c transportConfig) httpTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if c.proxy != "" {
+		proxyURL, err := url.Parse(c.proxy)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", c.proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if c.caFile == "" && c.clientCertFile == "" && c.clientKeyFile == "" && c.tlsServerName == "" {
+		return transport, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: c.tlsServerName,
+	}
+
+	if c.caFile != "" {
+		bundle, err := os.ReadFile(c.caFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA bundle: %w", err)
+		}
+		// trust the system certificate authorities too, the proxy might only intercept some hosts
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in CA bundle %s", c.caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.clientCertFile != "" || c.clientKeyFile != "" {
+		if c.clientCertFile == "" || c.clientKeyFile == "" {
+			return nil, errors.New("both, the client certificate and key, are required for mTLS")
+		}
+		cert, err := tls.LoadX509KeyPair(c.clientCertFile, c.clientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// apiTokenTransport authenticates every request with an API token. We set the header ourselves
+// when we pass our own HTTP client to mondoo-go, the client might be used as is.
+type apiTokenTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (t *apiTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a RoundTripper must not modify the request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,200 @@
+// Copyright (c) Mondoo, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePEM writes a PEM block into a temporary file and returns its path.
+func writePEM(t *testing.T, name string, blockType string, data []byte) string {
+	file := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data}), 0o600))
+	return file
+}
+
+// writeClientCertificate generates a self-signed client certificate and returns the path
+// of the certificate and key files.
+func writeClientCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyData, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return writePEM(t, "client.crt", "CERTIFICATE", cert), writePEM(t, "client.key", "EC PRIVATE KEY", keyData)
+}
+
+func getWithTransport(t *testing.T, config transportConfig, url string) error {
+	transport, err := config.httpTransport()
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: transport}).Get(url)
+	if err == nil {
+		resp.Body.Close()
+	}
+	return err
+}
+
+func TestTransportConfigCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caFile := writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+
+	assert.Error(t, getWithTransport(t, transportConfig{}, server.URL))
+	assert.NoError(t, getWithTransport(t, transportConfig{caFile: caFile}, server.URL))
+
+	// the certificate of the test server is valid for example.com
+	assert.NoError(t, getWithTransport(t, transportConfig{caFile: caFile, tlsServerName: "example.com"}, server.URL))
+	assert.Error(t, getWithTransport(t, transportConfig{caFile: caFile, tlsServerName: "mondoo.invalid"}, server.URL))
+}
+
+func TestTransportConfigClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+	caFile := writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+	certFile, keyFile := writeClientCertificate(t)
+
+	assert.Error(t, getWithTransport(t, transportConfig{caFile: caFile}, server.URL))
+	assert.NoError(t, getWithTransport(t, transportConfig{caFile: caFile, clientCertFile: certFile, clientKeyFile: keyFile}, server.URL))
+}
+
+func TestTransportConfigProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	assert.NoError(t, getWithTransport(t, transportConfig{proxy: proxy.URL}, "http://api.mondoo.invalid/query"))
+	assert.Equal(t, "http://api.mondoo.invalid/query", proxied)
+}
+
+func TestTransportConfigConfigured(t *testing.T) {
+	assert.False(t, transportConfig{}.configured())
+	assert.True(t, transportConfig{tlsServerName: "example.com"}.configured())
+}
+
+func TestAPITokenTransport(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: &apiTokenTransport{token: "token", next: http.DefaultTransport}}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "Bearer token", authorization)
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestTransportConfigInvalid(t *testing.T) {
+	emptyFile := filepath.Join(t.TempDir(), "empty.pem")
+	require.NoError(t, os.WriteFile(emptyFile, []byte{}, 0o600))
+	certFile, _ := writeClientCertificate(t)
+
+	tests := []struct {
+		name   string
+		config transportConfig
+	}{
+		{
+			name:   "Invalid proxy",
+			config: transportConfig{proxy: "proxy.example.com"},
+		},
+		{
+			name:   "Missing CA bundle",
+			config: transportConfig{caFile: filepath.Join(t.TempDir(), "missing.pem")},
+		},
+		{
+			name:   "CA bundle without certificates",
+			config: transportConfig{caFile: emptyFile},
+		},
+		{
+			name:   "Client certificate without key",
+			config: transportConfig{clientCertFile: certFile},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.config.httpTransport()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAccProviderAuthorization(t *testing.T) {
+	if fakeAPI == nil {
+		t.Skip("requires the fake Mondoo API")
+	}
+	orgID, err := getOrgId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAuthorization := func(*terraform.State) error {
+		if authorization := fakeAPI.lastAuthorization(); authorization != "Bearer "+fakeAPIToken {
+			return fmt.Errorf("expected the API token in the Authorization header, got %q", authorization)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// the HTTP client of mondoo-go
+			{
+				Config: testAccOrganizationDataSourceConfig(orgID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mondoo_organization.org", "id", orgID),
+					checkAuthorization,
+				),
+			},
+			// our own HTTP client, the fake API does not use TLS so the server name is ignored
+			{
+				Config: `
+provider "mondoo" {
+  tls_server_name = "api.mondoo.invalid"
+}
+` + testAccOrganizationDataSourceConfig(orgID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mondoo_organization.org", "id", orgID),
+					checkAuthorization,
+				),
+			},
+		},
+	})
+}